@@ -0,0 +1,116 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBayesianMassFunction(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf, err := NewBayesianMassFunction(nil, map[string]float64{
+		"yellow": 0.3, "red": 0.5, "green": 0.2,
+	})
+	assert.Nil(err)
+	assert.False(mf.Bound())
+	assert.Equal([]string{"green", "red", "yellow"}, mf.Frame().Hypotheses())
+	assert.InDelta(0.5, mf.Get(K("red")), tolerance)
+	assert.InDelta(0.3, mf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.2, mf.Get(K("green")), tolerance)
+	assert.True(mf.Valid())
+	assert.True(mf.IsBayesian())
+
+	frame, _ := NewFrame("red", "yellow", "green", "blue")
+	mf, err = NewBayesianMassFunction(frame, map[string]float64{
+		"red": 0.5, "green": 0.5,
+	})
+	assert.Nil(err)
+	assert.True(mf.Frame() == frame)
+	assert.True(mf.Bound())
+	assert.InDelta(0.5, mf.Get(K("green")), tolerance)
+	assert.InDelta(0.0, mf.Get(K("blue")), tolerance)
+
+	_, err = NewBayesianMassFunction(frame, map[string]float64{"purple": 1.0})
+	assert.NotNil(err)
+	_, err = NewBayesianMassFunction(nil, map[string]float64{"Red": 1.0})
+	assert.NotNil(err)
+	_, err = NewBayesianMassFunction(nil, map[string]float64{"red": 1.5, "green": -0.5})
+	assert.NotNil(err)
+	_, err = NewBayesianMassFunction(nil, map[string]float64{"red": 0.5, "green": 0.4})
+	assert.NotNil(err)
+}
+
+func TestIsBayesian(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(trafficLight().IsBayesian())
+	assert.True(trafficLight().Pignistic().IsBayesian())
+
+	mf := &MassFunction{}
+	mf.SetOpenWorld(true)
+	mf.Set(K("a"), 0.8)
+	mf.Set(K(), 0.2)
+	assert.False(mf.IsBayesian())
+}
+
+func TestBayesianApproximation(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.0001
+
+	mf := trafficLight()
+	bmf := mf.BayesianApproximation()
+	assert.True(bmf.IsBayesian())
+	assert.InDelta(0.41481, bmf.Get(K("red")), tolerance)
+	assert.InDelta(0.33333, bmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.25185, bmf.Get(K("green")), tolerance)
+
+	// The approximation of a combination is the combination of the
+	// approximations
+	other := &MassFunction{}
+	other.Set(K("red"), 0.2)
+	other.Set(K("yellow", "green"), 0.5)
+	other.Set(K("red", "yellow", "green"), 0.3)
+	combined := CombineConjunctive(mf, other).BayesianApproximation()
+	approximated := CombineBayesian(bmf, other.BayesianApproximation())
+	for _, h := range []string{"red", "yellow", "green"} {
+		assert.InDelta(combined.Get(K(h)), approximated.Get(K(h)), tolerance, h)
+	}
+}
+
+func TestCombineBayesian(t *testing.T) {
+	assert := assert.New(t)
+	// Dempster's rule rounds after each pairwise combination
+	const tolerance = 0.0001
+
+	mf1, _ := NewBayesianMassFunction(nil, map[string]float64{
+		"red": 0.5, "yellow": 0.3, "green": 0.2,
+	})
+	mf2, _ := NewBayesianMassFunction(nil, map[string]float64{
+		"red": 0.2, "yellow": 0.4, "green": 0.4,
+	})
+	mf3, _ := NewBayesianMassFunction(nil, map[string]float64{
+		"red": 0.6, "yellow": 0.4,
+	})
+
+	// The shortcut agrees with Dempster's rule
+	for _, mfns := range [][]*MassFunction{{mf1}, {mf1, mf2}, {mf1, mf2, mf3}} {
+		expected := CombineConjunctive(mfns...)
+		cf := CombineBayesian(mfns...)
+		assert.NotNil(cf)
+		assert.True(cf.IsBayesian())
+		assert.True(cf.Valid())
+		for _, p := range expected.Powerset() {
+			assert.InDelta(expected.Get(p), cf.Get(p), tolerance, p.String())
+		}
+	}
+
+	assert.Nil(CombineBayesian())
+	assert.Nil(CombineBayesian(mf1, trafficLight()))
+	green, _ := NewBayesianMassFunction(nil, map[string]float64{"green": 1.0})
+	assert.Nil(CombineBayesian(mf3, green))
+	frame, _ := NewFrame("a", "b")
+	other, _ := NewBayesianMassFunction(frame, map[string]float64{"a": 1.0})
+	assert.Nil(CombineBayesian(mf1, other))
+}
@@ -5,6 +5,13 @@ type BeliefFunction struct {
 	Function
 }
 
+// NewBeliefFunction creates an empty BeliefFunction bound to the given frame.
+func NewBeliefFunction(frame *Frame) *BeliefFunction {
+	bf := &BeliefFunction{}
+	bf.bind(frame, true)
+	return bf
+}
+
 // Valid verifies that a given BeliefFunction meets the defined requirements for
 // one. All probabilities must be in the range 0.0 >= p >= 1.0.
 func (bf *BeliefFunction) Valid() bool {
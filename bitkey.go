@@ -0,0 +1,204 @@
+package evidence
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// bitKey is an unexported hashable map key representing a subset of a Frame as
+// a bitmask, where bit i is set if the frame's i-th hypothesis is part of the
+// subset. Frames of up to 64 hypotheses fit entirely within lo and never
+// allocate. Larger frames pack each additional 64-bit word into hi in
+// little-endian order, with trailing zero words trimmed so that equal subsets
+// always have equal keys.
+type bitKey struct {
+	lo uint64
+	hi string
+}
+
+const wordBits = 64
+
+// hiWords returns the number of additional words stored in hi.
+func (bk bitKey) hiWords() int {
+	return len(bk.hi) / 8
+}
+
+// hiWord returns the i-th additional word, or zero if it isn't stored.
+func (bk bitKey) hiWord(i int) uint64 {
+	if i >= bk.hiWords() {
+		return 0
+	}
+	var w uint64
+	for b := 7; b >= 0; b-- {
+		w = w<<8 | uint64(bk.hi[i*8+b])
+	}
+	return w
+}
+
+// packWords encodes additional words into the hi representation, trimming any
+// trailing zero words.
+func packWords(ws []uint64) string {
+	n := len(ws)
+	for n > 0 && ws[n-1] == 0 {
+		n--
+	}
+	if n == 0 {
+		return ""
+	}
+	buf := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(buf[i*8:], ws[i])
+	}
+	return string(buf)
+}
+
+// combineWords applies a word-wise operation to both keys.
+func (bk bitKey) combineWords(obk bitKey, op func(uint64, uint64) uint64) bitKey {
+	n := bk.hiWords()
+	if obk.hiWords() > n {
+		n = obk.hiWords()
+	}
+	ws := make([]uint64, n)
+	for i := range ws {
+		ws[i] = op(bk.hiWord(i), obk.hiWord(i))
+	}
+	return bitKey{lo: op(bk.lo, obk.lo), hi: packWords(ws)}
+}
+
+// singletonBitKey returns the bitKey containing only the i-th hypothesis.
+func singletonBitKey(i int) bitKey {
+	return bitKey{}.With(i)
+}
+
+// With returns a copy of the bitKey that includes the i-th hypothesis.
+func (bk bitKey) With(i int) bitKey {
+	if i < wordBits {
+		return bitKey{lo: bk.lo | 1<<uint(i), hi: bk.hi}
+	}
+	ws := make([]uint64, bk.hiWords())
+	for j := range ws {
+		ws[j] = bk.hiWord(j)
+	}
+	w := i/wordBits - 1
+	for len(ws) <= w {
+		ws = append(ws, 0)
+	}
+	ws[w] |= 1 << uint(i%wordBits)
+	return bitKey{lo: bk.lo, hi: packWords(ws)}
+}
+
+// Has returns true if the i-th hypothesis is part of the bitKey.
+func (bk bitKey) Has(i int) bool {
+	if i < wordBits {
+		return bk.lo&(1<<uint(i)) != 0
+	}
+	return bk.hiWord(i/wordBits-1)&(1<<uint(i%wordBits)) != 0
+}
+
+// IsEmpty returns true if the bitKey represents the empty set.
+func (bk bitKey) IsEmpty() bool {
+	return bk.lo == 0 && bk.hi == ""
+}
+
+// Len returns the number of hypotheses in the bitKey.
+func (bk bitKey) Len() int {
+	n := bits.OnesCount64(bk.lo)
+	for i := 0; i < bk.hiWords(); i++ {
+		n += bits.OnesCount64(bk.hiWord(i))
+	}
+	return n
+}
+
+// Elements returns the indices of the hypotheses in the bitKey in ascending
+// order.
+func (bk bitKey) Elements() (is []int) {
+	is = make([]int, 0, bk.Len())
+	for w := 0; w <= bk.hiWords(); w++ {
+		word := bk.lo
+		if w > 0 {
+			word = bk.hiWord(w - 1)
+		}
+		for word != 0 {
+			b := bits.TrailingZeros64(word)
+			is = append(is, w*wordBits+b)
+			word &= word - 1
+		}
+	}
+	return is
+}
+
+// Intersect returns the bitKey that would be the intersection of the two keys.
+func (bk bitKey) Intersect(obk bitKey) bitKey {
+	if bk.hi == "" || obk.hi == "" {
+		return bitKey{lo: bk.lo & obk.lo}
+	}
+	return bk.combineWords(obk, func(a, b uint64) uint64 { return a & b })
+}
+
+// Union returns the bitKey that would be the union of the two keys.
+func (bk bitKey) Union(obk bitKey) bitKey {
+	if obk.hi == "" {
+		return bitKey{lo: bk.lo | obk.lo, hi: bk.hi}
+	}
+	if bk.hi == "" {
+		return bitKey{lo: bk.lo | obk.lo, hi: obk.hi}
+	}
+	return bk.combineWords(obk, func(a, b uint64) uint64 { return a | b })
+}
+
+// Difference returns the bitKey containing the hypotheses of this key that are
+// not part of the other key.
+func (bk bitKey) Difference(obk bitKey) bitKey {
+	if bk.hi == "" {
+		return bitKey{lo: bk.lo &^ obk.lo}
+	}
+	return bk.combineWords(obk, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// Intersects returns true if the two keys have at least one hypothesis in
+// common.
+func (bk bitKey) Intersects(obk bitKey) bool {
+	if bk.lo&obk.lo != 0 {
+		return true
+	}
+	for i := 0; i < bk.hiWords() && i < obk.hiWords(); i++ {
+		if bk.hiWord(i)&obk.hiWord(i) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSubset returns true if every hypothesis in this key is part of the other
+// key.
+func (bk bitKey) IsSubset(obk bitKey) bool {
+	if bk.lo&^obk.lo != 0 {
+		return false
+	}
+	for i := 0; i < bk.hiWords(); i++ {
+		if bk.hiWord(i)&^obk.hiWord(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every hypothesis in the other key is part of this
+// key.
+func (bk bitKey) IsSuperset(obk bitKey) bool {
+	return obk.IsSubset(bk)
+}
+
+// less orders bitKeys numerically, as though they were arbitrary-width
+// unsigned integers.
+func (bk bitKey) less(obk bitKey) bool {
+	if bk.hiWords() != obk.hiWords() {
+		return bk.hiWords() < obk.hiWords()
+	}
+	for i := bk.hiWords() - 1; i >= 0; i-- {
+		if a, b := bk.hiWord(i), obk.hiWord(i); a != b {
+			return a < b
+		}
+	}
+	return bk.lo < obk.lo
+}
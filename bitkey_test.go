@@ -0,0 +1,124 @@
+package evidence
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bk(is ...int) (k bitKey) {
+	for _, i := range is {
+		k = k.With(i)
+	}
+	return k
+}
+
+func TestBitKeySmall(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(bitKey{}.IsEmpty())
+	assert.False(bk(0).IsEmpty())
+	assert.Equal(bitKey{lo: 5}, bk(0, 2))
+	assert.Equal(bk(0, 2), bk(2, 0, 2))
+	assert.True(bk(0, 2).Has(2))
+	assert.False(bk(0, 2).Has(1))
+	assert.Equal(2, bk(0, 2).Len())
+	assert.Equal([]int{0, 2}, bk(2, 0).Elements())
+	assert.Equal([]int{}, bitKey{}.Elements())
+
+	assert.Equal(bk(1), bk(0, 1).Intersect(bk(1, 2)))
+	assert.Equal(bk(0, 1, 2), bk(0, 1).Union(bk(1, 2)))
+	assert.Equal(bk(0), bk(0, 1).Difference(bk(1, 2)))
+	assert.True(bk(0, 1).Intersects(bk(1, 2)))
+	assert.False(bk(0).Intersects(bk(1, 2)))
+	assert.True(bk(1).IsSubset(bk(1, 2)))
+	assert.True(bitKey{}.IsSubset(bk(1, 2)))
+	assert.False(bk(0, 1).IsSubset(bk(1, 2)))
+	assert.True(bk(1, 2).IsSuperset(bk(2)))
+	assert.True(bk(1).less(bk(0, 1)))
+	assert.False(bk(0, 1).less(bk(1)))
+}
+
+func TestBitKeyLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(bk(3), bk(3, 200).Intersect(bk(3, 130)))
+	assert.Equal("", bk(3, 200).Intersect(bk(3, 130)).hi)
+	assert.Equal(bk(3, 130, 200), bk(3, 200).Union(bk(3, 130)))
+	assert.Equal(bk(200), bk(3, 200).Difference(bk(3, 130)))
+	// Trailing zero words are trimmed so equal sets have equal keys
+	assert.Equal(bk(3), bk(3, 200).Difference(bk(200)))
+	assert.Equal(bk(64, 65), bk(64).Union(bk(65)))
+	assert.True(bk(64, 200).Has(200))
+	assert.False(bk(64, 200).Has(199))
+	assert.Equal(3, bk(0, 64, 200).Len())
+	assert.Equal([]int{0, 64, 200}, bk(200, 64, 0).Elements())
+	assert.True(bk(64, 200).Intersects(bk(200)))
+	assert.False(bk(64, 200).Intersects(bk(65, 201)))
+	assert.True(bk(1, 200).IsSubset(bk(1, 64, 200)))
+	assert.False(bk(1, 200).IsSubset(bk(1, 64)))
+	assert.True(bk(1, 64).less(bk(200)))
+	assert.True(bk(65).less(bk(1, 66)))
+}
+
+func TestFrameBitKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("c", "a", "b")
+	k, ok := fr.key(K("a", "c"))
+	assert.True(ok)
+	assert.Equal(bk(0, 1), k)
+	_, ok = fr.key(K("a", "d"))
+	assert.False(ok)
+	assert.Equal(K("a", "c"), fr.functionKey(k))
+	assert.Equal(bk(0, 1, 2), fr.universe())
+	assert.Equal(bk(2), fr.complement(k))
+	assert.Len(fr.powerset(), 8)
+
+	other, _ := NewFrame("a", "b", "c", "d")
+	translate := other.translator(fr)
+	assert.Equal(bk(0, 2), translate(k))
+
+	hypotheses := make([]string, 130)
+	for i := range hypotheses {
+		hypotheses[i] = fmt.Sprintf("h%d", i)
+	}
+	big, _ := NewFrame(hypotheses...)
+	assert.Equal(130, big.universe().Len())
+	k, ok = big.key(K("h0", "h64", "h129"))
+	assert.True(ok)
+	assert.Equal(bk(0, 64, 129), k)
+	assert.Equal(127, big.complement(k).Len())
+	assert.Equal(K("h0", "h129", "h64"), big.functionKey(k))
+}
+
+func TestCombineLargeFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	hypotheses := make([]string, 100)
+	for i := range hypotheses {
+		hypotheses[i] = fmt.Sprintf("h%d", i)
+	}
+	fr, _ := NewFrame(hypotheses...)
+
+	mf1 := NewMassFunction(fr)
+	mf1.Set(K("h1", "h70"), 0.6)
+	mf1.Set(fr.Universe(), 0.4)
+
+	mf2 := NewMassFunction(fr)
+	mf2.Set(K("h70", "h99"), 0.5)
+	mf2.Set(K("h1"), 0.5)
+
+	cf := CombineConjunctive(mf1, mf2)
+	assert.InDelta(0.3, cf.Get(K("h70")), 0.00001)
+	assert.InDelta(0.5, cf.Get(K("h1")), 0.00001)
+	assert.InDelta(0.2, cf.Get(K("h70", "h99")), 0.00001)
+	assert.True(cf.Valid())
+
+	df := CombineDisjunctive(mf1, mf2)
+	assert.InDelta(0.3, df.Get(K("h1", "h70", "h99")), 0.00001)
+	assert.InDelta(0.3, df.Get(K("h1", "h70")), 0.00001)
+	assert.InDelta(0.4, df.Get(fr.Universe()), 0.00001)
+	assert.True(df.Valid())
+}
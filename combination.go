@@ -1,87 +1,183 @@
 package evidence
 
+import (
+	"math"
+	"sort"
+)
+
+// alignFrames determines the frame that a combination of MassFunctions will be
+// defined over, returning an error if the MassFunctions' frames can't be
+// reconciled with each other.
+func alignFrames(mfns ...*MassFunction) (*Frame, bool, error) {
+	fns := make([]*Function, len(mfns))
+	for i, mf := range mfns {
+		fns[i] = &mf.Function
+	}
+	return commonFrame(fns...)
+}
+
+// newCombined creates an empty MassFunction over the frame shared by the
+// MassFunctions being combined.
+func newCombined(frame *Frame, bound bool) (cf *MassFunction) {
+	cf = &MassFunction{}
+	cf.bind(frame, bound)
+	return cf
+}
+
 // combinePairwise takes a pairwise combination function and two or more
 // MassFunctions and returns a new MassFunction according to the rule of
 // combination given by the combination function. Returns nil if no
-// MassFunctions are provided.
-func combinePairwise(combiner func(*MassFunction, *MassFunction) *MassFunction,
+// MassFunctions are provided or if their frames are incompatible.
+func combinePairwise(
+	combiner func(*Frame, bool, *MassFunction, *MassFunction) *MassFunction,
 	mfns ...*MassFunction) *MassFunction {
 	if len(mfns) == 0 {
 		return nil
 	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
 	accumulator := mfns[0]
 	for _, mf := range mfns[1:] {
-		accumulator = combiner(accumulator, mf)
+		accumulator = combiner(frame, bound, accumulator, mf)
 	}
 	return accumulator
 }
 
+// sortedFocals orders a map of masses by bitKey so that results don't depend on
+// map iteration order.
+func sortedFocals(masses map[bitKey]float64) (fs []focal) {
+	fs = make([]focal, 0, len(masses))
+	for k, v := range masses {
+		fs = append(fs, focal{key: k, value: v})
+	}
+	sort.Slice(fs, func(i, j int) bool {
+		return fs[i].key.less(fs[j].key)
+	})
+	return fs
+}
+
+// conjunctiveMasses returns the unnormalized conjunctive combination of the
+// MassFunctions over the given frame, leaving any conflict on the empty set.
+// Unlike its normalized counterpart, this combination is associative, so the
+// MassFunctions may simply be folded together.
+func conjunctiveMasses(frame *Frame, mfns ...*MassFunction) map[bitKey]float64 {
+	masses := map[bitKey]float64{frame.universe(): 1.0}
+	for _, mf := range mfns {
+		next := make(map[bitKey]float64)
+		accumulated := sortedFocals(masses)
+		for _, f := range mf.focalSets(frame) {
+			for _, a := range accumulated {
+				next[a.key.Intersect(f.key)] += a.value * f.value
+			}
+		}
+		masses = next
+	}
+	return masses
+}
+
 // CombineConjunctive takes two or more MassFunctions and returns a new
-// MassFunction according to Dempster's rule of combination. Returns
-// nil if no MassFunctions are provided.
+// MassFunction according to Dempster's rule of combination. The degree of
+// conflict that the rule normalizes away is reported by Conflict. Returns
+// nil if no MassFunctions are provided or if their frames are incompatible.
 func CombineConjunctive(mfns ...*MassFunction) *MassFunction {
 	return combinePairwise(pairwiseCombineConjunctive, mfns...)
 }
 
 // pairwiseCombineConjunctive takes two MassFunctions and returns a new
 // MassFunction according to Dempster's rule of combination.
-func pairwiseCombineConjunctive(mf1 *MassFunction, mf2 *MassFunction) (cf *MassFunction) {
-	cf = &MassFunction{}
-	cf.init()
-	for _, p1 := range mf1.Powerset() {
-		for _, p2 := range mf2.Powerset() {
-			intersect := p1.Intersect(p2)
-			cf.Set(intersect, cf.getUnsafe(intersect)+(mf1.Get(p1)*mf2.Get(p2)))
+func pairwiseCombineConjunctive(frame *Frame, bound bool,
+	mf1 *MassFunction, mf2 *MassFunction) (cf *MassFunction) {
+	cf = newCombined(frame, bound)
+	masses := make(map[bitKey]float64)
+	focals2 := mf2.focalSets(frame)
+	for _, f1 := range mf1.focalSets(frame) {
+		for _, f2 := range focals2 {
+			masses[f1.key.Intersect(f2.key)] += f1.value * f2.value
 		}
 	}
-	for _, p := range mf1.Powerset() {
-		if p != K() {
-			newP := cf.getUnsafe(p) / (1.0 - cf.getUnsafe(K()))
-			cf.Set(p, newP)
+	conflict := masses[bitKey{}]
+	for p, v := range masses {
+		if !p.IsEmpty() {
+			cf.setUnsafe(p, v/(1.0-conflict))
 		}
 	}
-	cf.Set(K(), 0.0)
+	cf.setUnsafe(bitKey{}, 0.0)
+	return cf
+}
+
+// CombineConjunctiveUnnormalized takes two or more MassFunctions and returns a
+// new open-world MassFunction according to Smets' unnormalized conjunctive
+// rule, which leaves conflicting mass on the empty set. Returns nil if no
+// MassFunctions are provided or if their frames are incompatible.
+func CombineConjunctiveUnnormalized(mfns ...*MassFunction) (cf *MassFunction) {
+	if len(mfns) == 0 {
+		return nil
+	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	cf = newCombined(frame, bound)
+	cf.openWorld = true
+	masses := conjunctiveMasses(frame, mfns...)
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
+	if _, ok := masses[bitKey{}]; !ok {
+		cf.setUnsafe(bitKey{}, 0.0)
+	}
 	return cf
 }
 
 // CombineDisjunctive takes two or more MassFunctions and returns a new
 // MassFunction according to the disjunctive rule of combination. Returns
-// nil if no MassFunctions are provided.
+// nil if no MassFunctions are provided or if their frames are incompatible.
 func CombineDisjunctive(mfns ...*MassFunction) (cf *MassFunction) {
 	return combinePairwise(pairwiseCombineDisjunctive, mfns...)
 }
 
 // pairwiseCombineDisjunctive takes two MassFunctions and returns a new
 // MassFunction according to the disjunctive rule of combination.
-func pairwiseCombineDisjunctive(mf1 *MassFunction, mf2 *MassFunction) (cf *MassFunction) {
-	cf = &MassFunction{}
-	cf.init()
-	for _, p1 := range mf1.Powerset() {
-		for _, p2 := range mf2.Powerset() {
-			union := p1.Union(p2)
-			cf.Set(union, cf.getUnsafe(union)+(mf1.Get(p1)*mf2.Get(p2)))
+func pairwiseCombineDisjunctive(frame *Frame, bound bool,
+	mf1 *MassFunction, mf2 *MassFunction) (cf *MassFunction) {
+	cf = newCombined(frame, bound)
+	masses := make(map[bitKey]float64)
+	focals2 := mf2.focalSets(frame)
+	for _, f1 := range mf1.focalSets(frame) {
+		for _, f2 := range focals2 {
+			masses[f1.key.Union(f2.key)] += f1.value * f2.value
 		}
 	}
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
 	return cf
 }
 
 // CombineMurphyAverage takes two or more MassFunctions and returns a new
 // MassFunction according to Murphy's rule of combination, first averaging
 // the masses and then performing a conjunctive combination. Returns
-// nil if no MassFunctions are provided.
+// nil if no MassFunctions are provided or if their frames are incompatible.
 func CombineMurphyAverage(mfns ...*MassFunction) (cf *MassFunction) {
 	if len(mfns) == 0 {
 		return nil
 	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
 	count := len(mfns)
-	cf = &MassFunction{}
-	cf.init()
-	for _, p1 := range mfns[0].Powerset() {
-		sum := 0.0
-		for _, mf := range mfns {
-			sum += mf.getUnsafe(p1)
+	cf = newCombined(frame, bound)
+	sums := make(map[bitKey]float64)
+	for _, mf := range mfns {
+		for _, f := range mf.focalSets(frame) {
+			sums[f.key] += f.value
 		}
-		cf.Set(p1, sum/float64(count))
+	}
+	for p, sum := range sums {
+		cf.setUnsafe(p, sum/float64(count))
 	}
 	cfRepeat := make([]*MassFunction, count)
 	for i := 0; i < count; i++ {
@@ -89,3 +185,214 @@ func CombineMurphyAverage(mfns ...*MassFunction) (cf *MassFunction) {
 	}
 	return CombineConjunctive(cfRepeat...)
 }
+
+// CombinePCR5 takes two or more MassFunctions and returns a new MassFunction
+// according to the PCR5 rule, which hands the product mass of each pair of
+// disjoint focal sets back to them in proportion to their masses. Returns nil
+// if no MassFunctions are provided or if their frames are incompatible.
+func CombinePCR5(mfns ...*MassFunction) (cf *MassFunction) {
+	return combinePairwise(pairwiseCombinePCR5, mfns...)
+}
+
+// pairwiseCombinePCR5 takes two MassFunctions and returns a new MassFunction
+// according to the PCR5 rule of combination.
+func pairwiseCombinePCR5(frame *Frame, bound bool,
+	mf1 *MassFunction, mf2 *MassFunction) (cf *MassFunction) {
+	cf = newCombined(frame, bound)
+	masses := make(map[bitKey]float64)
+	focals2 := mf2.focalSets(frame)
+	for _, f1 := range mf1.focalSets(frame) {
+		for _, f2 := range focals2 {
+			product := f1.value * f2.value
+			intersect := f1.key.Intersect(f2.key)
+			if !intersect.IsEmpty() {
+				masses[intersect] += product
+				continue
+			}
+			// The partial conflict goes back to the focal sets that caused it
+			sum := f1.value + f2.value
+			masses[f1.key] += f1.value * product / sum
+			masses[f2.key] += f2.value * product / sum
+		}
+	}
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
+	if _, ok := masses[bitKey{}]; !ok {
+		cf.setUnsafe(bitKey{}, 0.0)
+	}
+	return cf
+}
+
+// CombinePCR6 takes two or more MassFunctions and returns a new MassFunction
+// according to Martin and Osswald's PCR6 rule, the generalization of PCR5 to
+// any number of sources. Returns nil if no MassFunctions are provided or if
+// their frames are incompatible.
+func CombinePCR6(mfns ...*MassFunction) (cf *MassFunction) {
+	if len(mfns) == 0 {
+		return nil
+	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	cf = newCombined(frame, bound)
+	focals := make([][]focal, len(mfns))
+	for i, mf := range mfns {
+		focals[i] = mf.focalSets(frame)
+	}
+	masses := make(map[bitKey]float64)
+	chosen := make([]focal, len(mfns))
+	var combine func(depth int, intersect bitKey, product float64, sum float64)
+	combine = func(depth int, intersect bitKey, product float64, sum float64) {
+		if depth == len(focals) {
+			if !intersect.IsEmpty() {
+				masses[intersect] += product
+				return
+			}
+			for _, f := range chosen {
+				masses[f.key] += f.value * product / sum
+			}
+			return
+		}
+		for _, f := range focals[depth] {
+			chosen[depth] = f
+			combine(depth+1, intersect.Intersect(f.key), product*f.value, sum+f.value)
+		}
+	}
+	combine(0, frame.universe(), 1.0, 0.0)
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
+	if _, ok := masses[bitKey{}]; !ok {
+		cf.setUnsafe(bitKey{}, 0.0)
+	}
+	return cf
+}
+
+// CombineYager takes two or more MassFunctions and returns a new MassFunction
+// according to Yager's rule of combination, which assigns conflicting mass to
+// the whole frame. Returns nil if no MassFunctions are provided or if their
+// frames are incompatible.
+func CombineYager(mfns ...*MassFunction) (cf *MassFunction) {
+	if len(mfns) == 0 {
+		return nil
+	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	cf = newCombined(frame, bound)
+	masses := conjunctiveMasses(frame, mfns...)
+	conflict := masses[bitKey{}]
+	delete(masses, bitKey{})
+	masses[frame.universe()] += conflict
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
+	cf.setUnsafe(bitKey{}, 0.0)
+	return cf
+}
+
+// CombineDuboisPrade takes two or more MassFunctions and returns a new
+// MassFunction according to Dubois and Prade's hybrid rule of combination,
+// which assigns the mass of disjoint focal sets to their union. Returns nil if
+// no MassFunctions are provided or if their frames are incompatible.
+func CombineDuboisPrade(mfns ...*MassFunction) (cf *MassFunction) {
+	if len(mfns) == 0 {
+		return nil
+	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	cf = newCombined(frame, bound)
+	focals := make([][]focal, len(mfns))
+	for i, mf := range mfns {
+		focals[i] = mf.focalSets(frame)
+	}
+	// The rule isn't associative, so every combination of focal sets across
+	// all of the MassFunctions is considered at once
+	masses := make(map[bitKey]float64)
+	var combine func(depth int, intersect bitKey, union bitKey, product float64)
+	combine = func(depth int, intersect bitKey, union bitKey, product float64) {
+		if depth == len(focals) {
+			if intersect.IsEmpty() {
+				masses[union] += product
+			} else {
+				masses[intersect] += product
+			}
+			return
+		}
+		for _, f := range focals[depth] {
+			combine(depth+1, intersect.Intersect(f.key), union.Union(f.key),
+				product*f.value)
+		}
+	}
+	combine(0, frame.universe(), bitKey{}, 1.0)
+	for p, v := range masses {
+		cf.setUnsafe(p, v)
+	}
+	if _, ok := masses[bitKey{}]; !ok {
+		cf.setUnsafe(bitKey{}, 0.0)
+	}
+	return cf
+}
+
+// combineWeights combines MassFunctions by decomposing each of them into
+// weights, taking the minimum weight for each possibility, and composing the
+// result back into a new open-world MassFunction.
+func combineWeights(mfns []*MassFunction,
+	decompose func([]float64) ([]float64, error),
+	compose func([]float64) []float64) (cf *MassFunction) {
+	if len(mfns) == 0 {
+		return nil
+	}
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	var combined []float64
+	for _, mf := range mfns {
+		v, err := mf.denseOver(frame)
+		if err != nil {
+			return nil
+		}
+		weights, err := decompose(v)
+		if err != nil {
+			return nil
+		}
+		if combined == nil {
+			combined = weights
+			continue
+		}
+		for i := range combined {
+			combined[i] = math.Min(combined[i], weights[i])
+		}
+	}
+	cf = newCombined(frame, bound)
+	cf.openWorld = true
+	cf.setDenseUnsafe(compose(combined), true)
+	if _, ok := cf.possibilities[bitKey{}]; !ok {
+		cf.setUnsafe(bitKey{}, 0.0)
+	}
+	return cf
+}
+
+// CombineCautious takes two or more MassFunctions and returns a new open-world
+// MassFunction according to Denœux's cautious rule, which keeps the smallest
+// conjunctive weight for each possibility. Returns nil if no MassFunctions are
+// provided, if any of them is dogmatic, or if their frames are incompatible or
+// too large to enumerate.
+func CombineCautious(mfns ...*MassFunction) (cf *MassFunction) {
+	return combineWeights(mfns, conjunctiveWeights, massesFromConjunctiveWeights)
+}
+
+// CombineBold takes two or more MassFunctions and returns a new open-world
+// MassFunction according to Denœux's bold rule, which keeps the smallest
+// disjunctive weight for each possibility. Returns nil if no MassFunctions are
+// provided, if any of them is normal, or if their frames are incompatible or
+// too large to enumerate.
+func CombineBold(mfns ...*MassFunction) (cf *MassFunction) {
+	return combineWeights(mfns, disjunctiveWeights, massesFromDisjunctiveWeights)
+}
@@ -270,6 +270,298 @@ func TestCombineConjunctive(t *testing.T) {
 	}
 }
 
+func TestCombineConjunctiveUnnormalized(t *testing.T) {
+	const tolerance = 0.0025
+
+	tcs := []struct {
+		name        string
+		mfns        func() []*MassFunction
+		expectedMfn func() *MassFunction
+	}{
+		{
+			name: "traffic light",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("red"), 0.35)
+				mf1.Set(K("yellow"), 0.25)
+				mf1.Set(K("green"), 0.15)
+				mf1.Set(K("red", "yellow"), 0.06)
+				mf1.Set(K("red", "green"), 0.05)
+				mf1.Set(K("yellow", "green"), 0.04)
+				mf1.Set(K("red", "yellow", "green"), 0.1)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("red"), 0.15)
+				mf2.Set(K("yellow"), 0.3)
+				mf2.Set(K("green"), 0.2)
+				mf2.Set(K("red", "yellow"), 0.03)
+				mf2.Set(K("red", "green"), 0.01)
+				mf2.Set(K("yellow", "green"), 0.01)
+				mf2.Set(K("red", "yellow", "green"), 0.3)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.3735)
+				cf.Set(K("red"), 0.2051)
+				cf.Set(K("yellow"), 0.2218)
+				cf.Set(K("green"), 0.1169)
+				cf.Set(K("red", "yellow"), 0.0228)
+				cf.Set(K("red", "green"), 0.0165)
+				cf.Set(K("yellow", "green"), 0.0134)
+				cf.Set(K("red", "yellow", "green"), 0.03)
+				return cf
+			},
+		},
+		{
+			name: "block decision factor out mass function",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("allow"), 0.35)
+				mf1.Set(K("deny"), 0.2)
+				mf1.Set(K("allow", "deny"), 0.45)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("allow"), 0.0)
+				mf2.Set(K("deny"), 0.0)
+				mf2.Set(K("allow", "deny"), 1.0)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("allow"), 0.35)
+				cf.Set(K("deny"), 0.2)
+				cf.Set(K("allow", "deny"), 0.45)
+				return cf
+			},
+		},
+		{
+			name: "block decision high conflict",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("allow"), 1.0)
+				mf1.Set(K("deny"), 0.0)
+				mf1.Set(K("allow", "deny"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("allow"), 0.0)
+				mf2.Set(K("deny"), 1.0)
+				mf2.Set(K("allow", "deny"), 0.0)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 1.0)
+				cf.Set(K("allow"), 0.0)
+				cf.Set(K("deny"), 0.0)
+				cf.Set(K("allow", "deny"), 0.0)
+				return cf
+			},
+		},
+		{
+			// https://www.mdpi.com/1424-8220/18/5/1487/pdf
+			name: "multi-sensor target recognition system 3x evidence",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("a"), 0.30)
+				mf1.Set(K("b"), 0.20)
+				mf1.Set(K("c"), 0.10)
+				mf1.Set(K("a", "b", "c"), 0.40)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.00)
+				mf2.Set(K("b"), 0.90)
+				mf2.Set(K("c"), 0.10)
+				mf2.Set(K("a", "b", "c"), 0.00)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.60)
+				mf3.Set(K("b"), 0.10)
+				mf3.Set(K("c"), 0.10)
+				mf3.Set(K("a", "b", "c"), 0.20)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.823)
+				cf.Set(K("a"), 0.00)
+				cf.Set(K("b"), 0.162)
+				cf.Set(K("c"), 0.015)
+				cf.Set(K("a", "b", "c"), 0.00)
+				return cf
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mfns := tc.mfns()
+			expectedMfn := tc.expectedMfn()
+			cf := CombineConjunctiveUnnormalized(mfns...)
+			assert.True(cf.OpenWorld())
+			assert.InDelta(expectedMfn.Get(K()), cf.Get(K()), tolerance)
+			for _, possibility := range cf.Possibilities() {
+				expectedValue := expectedMfn.Get(possibility)
+				value := cf.Get(possibility)
+				assert.InDelta(expectedValue, value, tolerance)
+			}
+			assert.True(cf.Valid())
+		})
+	}
+}
+
+func TestCombineCautious(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// The cautious rule is idempotent
+	mf := trafficLight()
+	cf := CombineCautious(mf, mf, mf)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(mf.Get(p), cf.Get(p), tolerance, p.String())
+	}
+	assert.True(cf.Valid())
+
+	// {a,b}^0.3 and {a,b}^0.6 combined with {b,c}^0.5
+	mf1 := &MassFunction{}
+	mf1.Set(K("a", "b"), 0.7)
+	mf1.Set(K("a", "b", "c"), 0.3)
+	mf2 := &MassFunction{}
+	mf2.Set(K("b"), 0.2)
+	mf2.Set(K("a", "b"), 0.2)
+	mf2.Set(K("b", "c"), 0.3)
+	mf2.Set(K("a", "b", "c"), 0.3)
+	cf = CombineCautious(mf1, mf2)
+	assert.True(cf.OpenWorld())
+	assert.InDelta(0.35, cf.Get(K("b")), tolerance)
+	assert.InDelta(0.35, cf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.15, cf.Get(K("b", "c")), tolerance)
+	assert.InDelta(0.15, cf.Get(K("a", "b", "c")), tolerance)
+	assert.True(cf.Valid())
+
+	// Dempster's rule counts the shared support for {a,b} twice
+	cf = CombineConjunctive(mf1, mf2)
+	assert.InDelta(0.41, cf.Get(K("b")), tolerance)
+
+	// Conflict stays on the empty set
+	mf3 := &MassFunction{}
+	mf3.Set(K("c"), 0.5)
+	mf3.Set(K("a", "b", "c"), 0.5)
+	cf = CombineCautious(mf1, mf3)
+	assert.InDelta(0.35, cf.Get(K()), tolerance)
+	assert.True(cf.Valid())
+
+	// Dogmatic mass functions are refused
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 1.0)
+	assert.Nil(CombineCautious(mf1, mf4))
+}
+
+func TestCombineBold(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// {a}_0.3 and {a}_0.4 combined disjunctively with {b}_0.5
+	mf1 := &MassFunction{}
+	mf1.SetOpenWorld(true)
+	mf1.Set(K(), 0.3)
+	mf1.Set(K("a"), 0.7)
+	mf1.Set(K("b"), 0.0)
+	mf2 := &MassFunction{}
+	mf2.SetOpenWorld(true)
+	mf2.Set(K(), 0.2)
+	mf2.Set(K("a"), 0.3)
+	mf2.Set(K("b"), 0.2)
+	mf2.Set(K("a", "b"), 0.3)
+	cf := CombineBold(mf1, mf2)
+	assert.True(cf.OpenWorld())
+	assert.InDelta(0.15, cf.Get(K()), tolerance)
+	assert.InDelta(0.35, cf.Get(K("a")), tolerance)
+	assert.InDelta(0.15, cf.Get(K("b")), tolerance)
+	assert.InDelta(0.35, cf.Get(K("a", "b")), tolerance)
+	assert.True(cf.Valid())
+
+	// The bold rule is idempotent
+	cf = CombineBold(mf2, mf2)
+	for _, p := range mf2.Powerset() {
+		assert.InDelta(mf2.Get(p), cf.Get(p), tolerance, p.String())
+	}
+
+	// Normal mass functions are refused
+	assert.Nil(CombineBold(mf1, trafficLight()))
+
+	// As are frames too large to enumerate
+	large, _ := largeFrame()
+	lmf := NewMassFunction(large)
+	lmf.SetOpenWorld(true)
+	lmf.Set(K(), 0.5)
+	lmf.Set(K("h0"), 0.5)
+	assert.Nil(CombineBold(lmf, lmf))
+	lmf.Set(K(), 0.0)
+	lmf.Set(K("h0"), 0.5)
+	lmf.Set(large.Universe(), 0.5)
+	assert.Nil(CombineCautious(lmf, lmf))
+}
+
+func TestCombineFrames(t *testing.T) {
+	assert := assert.New(t)
+
+	abc, _ := NewFrame("a", "b", "c")
+	abd, _ := NewFrame("a", "b", "d")
+
+	mf1 := NewMassFunction(abc)
+	mf1.Set(K("a"), 0.6)
+	mf1.Set(K("a", "b", "c"), 0.4)
+
+	mf2 := NewMassFunction(abd)
+	mf2.Set(K("b"), 0.5)
+	mf2.Set(K("a", "b", "d"), 0.5)
+
+	assert.Nil(CombineConjunctive(mf1, mf2))
+	assert.Nil(CombineDisjunctive(mf1, mf2))
+	assert.Nil(CombineMurphyAverage(mf1, mf2))
+
+	// An unbound MassFunction is aligned to the bound frame
+	mf3 := &MassFunction{}
+	mf3.Set(K("b"), 0.5)
+	mf3.Set(K("a", "b"), 0.5)
+	cf := CombineConjunctive(mf1, mf3)
+	assert.NotNil(cf)
+	assert.True(cf.Frame() == abc)
+	assert.InDelta(0.3/0.7, cf.Get(K("a")), 0.0001)
+	assert.InDelta(0.2/0.7, cf.Get(K("b")), 0.0001)
+	assert.InDelta(0.2/0.7, cf.Get(K("a", "b")), 0.0001)
+	assert.True(cf.Valid())
+
+	// Unbound MassFunctions are aligned to the union of their frames
+	mf4 := &MassFunction{}
+	mf4.Set(K("d"), 0.5)
+	mf4.Set(K("a", "b", "c", "d"), 0.5)
+	cf = CombineDisjunctive(mf3, mf4)
+	assert.NotNil(cf)
+	assert.False(cf.Bound())
+	assert.Equal(4, cf.Frame().Len())
+	assert.InDelta(0.25, cf.Get(K("b", "d")), 0.00001)
+	assert.InDelta(0.25, cf.Get(K("a", "b", "d")), 0.00001)
+	assert.InDelta(0.5, cf.Get(K("a", "b", "c", "d")), 0.00001)
+
+	// An unbound MassFunction that doesn't fit the bound frame is refused
+	assert.Nil(CombineConjunctive(mf1, mf4))
+}
+
 func TestCombineDisjunctive(t *testing.T) {
 	const tolerance = 0.0025
 
@@ -681,56 +973,622 @@ func TestCombineMurphyAverage(t *testing.T) {
 	}
 }
 
-func BenchmarkCombineConjunctive(b *testing.B) {
-	mf1 := &MassFunction{}
-	mf1.Set(K(), 0.0)
-	mf1.Set(K("a"), 0.30)
-	mf1.Set(K("b"), 0.20)
-	mf1.Set(K("c"), 0.10)
-	mf1.Set(K("a", "b", "c"), 0.40)
-
-	mf2 := &MassFunction{}
-	mf2.Set(K("a"), 0.00)
-	mf2.Set(K("b"), 0.90)
-	mf2.Set(K("c"), 0.10)
-	mf2.Set(K("a", "b", "c"), 0.00)
-
-	mf3 := &MassFunction{}
-	mf3.Set(K("a"), 0.60)
-	mf3.Set(K("b"), 0.10)
-	mf3.Set(K("c"), 0.10)
-	mf3.Set(K("a", "b", "c"), 0.20)
-
-	mf4 := &MassFunction{}
-	mf4.Set(K("a"), 0.70)
-	mf4.Set(K("b"), 0.10)
-	mf4.Set(K("c"), 0.10)
-	mf4.Set(K("a", "b", "c"), 0.10)
+func TestCombineYager(t *testing.T) {
+	const tolerance = 0.0025
 
-	mf5 := &MassFunction{}
-	mf5.Set(K("a"), 0.70)
-	mf5.Set(K("b"), 0.10)
-	mf5.Set(K("c"), 0.10)
-	mf5.Set(K("a", "b", "c"), 0.10)
+	tcs := []struct {
+		name        string
+		mfns        func() []*MassFunction
+		expectedMfn func() *MassFunction
+	}{
+		{
+			name: "traffic light",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("red"), 0.35)
+				mf1.Set(K("yellow"), 0.25)
+				mf1.Set(K("green"), 0.15)
+				mf1.Set(K("red", "yellow"), 0.06)
+				mf1.Set(K("red", "green"), 0.05)
+				mf1.Set(K("yellow", "green"), 0.04)
+				mf1.Set(K("red", "yellow", "green"), 0.1)
 
-	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("red"), 0.15)
+				mf2.Set(K("yellow"), 0.3)
+				mf2.Set(K("green"), 0.2)
+				mf2.Set(K("red", "yellow"), 0.03)
+				mf2.Set(K("red", "green"), 0.01)
+				mf2.Set(K("yellow", "green"), 0.01)
+				mf2.Set(K("red", "yellow", "green"), 0.3)
 
-	for n := 0; n < b.N; n++ {
-		CombineConjunctive(mfns...)
-	}
-}
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("red"), 0.2051)
+				cf.Set(K("yellow"), 0.2218)
+				cf.Set(K("green"), 0.1169)
+				cf.Set(K("red", "yellow"), 0.0228)
+				cf.Set(K("red", "green"), 0.0165)
+				cf.Set(K("yellow", "green"), 0.0134)
+				cf.Set(K("red", "yellow", "green"), 0.4035)
+				return cf
+			},
+		},
+		{
+			name: "block decision factor out mass function",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("allow"), 0.35)
+				mf1.Set(K("deny"), 0.2)
+				mf1.Set(K("allow", "deny"), 0.45)
 
-func BenchmarkCombineDisjunctive(b *testing.B) {
-	mf1 := &MassFunction{}
-	mf1.Set(K(), 0.0)
-	mf1.Set(K("a"), 0.30)
-	mf1.Set(K("b"), 0.20)
-	mf1.Set(K("c"), 0.10)
-	mf1.Set(K("a", "b", "c"), 0.40)
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("allow"), 0.0)
+				mf2.Set(K("deny"), 0.0)
+				mf2.Set(K("allow", "deny"), 1.0)
 
-	mf2 := &MassFunction{}
-	mf2.Set(K("a"), 0.00)
-	mf2.Set(K("b"), 0.90)
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("allow"), 0.35)
+				cf.Set(K("deny"), 0.2)
+				cf.Set(K("allow", "deny"), 0.45)
+				return cf
+			},
+		},
+		{
+			name: "block decision high conflict",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("allow"), 1.0)
+				mf1.Set(K("deny"), 0.0)
+				mf1.Set(K("allow", "deny"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("allow"), 0.0)
+				mf2.Set(K("deny"), 1.0)
+				mf2.Set(K("allow", "deny"), 0.0)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("allow"), 0.0)
+				cf.Set(K("deny"), 0.0)
+				cf.Set(K("allow", "deny"), 1.0)
+				return cf
+			},
+		},
+		{
+			// Zadeh's example, where Dempster's rule would be certain of the
+			// diagnosis that both doctors considered least likely
+			name: "zadeh paradox",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("meningitis"), 0.99)
+				mf1.Set(K("tumor"), 0.01)
+				mf1.Set(K("concussion"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("meningitis"), 0.0)
+				mf2.Set(K("tumor"), 0.01)
+				mf2.Set(K("concussion"), 0.99)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("tumor"), 0.0001)
+				cf.Set(K("meningitis", "tumor", "concussion"), 0.9999)
+				return cf
+			},
+		},
+		{
+			// https://www.mdpi.com/1424-8220/18/5/1487/pdf
+			name: "multi-sensor target recognition system 3x evidence",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("a"), 0.30)
+				mf1.Set(K("b"), 0.20)
+				mf1.Set(K("c"), 0.10)
+				mf1.Set(K("a", "b", "c"), 0.40)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.00)
+				mf2.Set(K("b"), 0.90)
+				mf2.Set(K("c"), 0.10)
+				mf2.Set(K("a", "b", "c"), 0.00)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.60)
+				mf3.Set(K("b"), 0.10)
+				mf3.Set(K("c"), 0.10)
+				mf3.Set(K("a", "b", "c"), 0.20)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.00)
+				cf.Set(K("b"), 0.162)
+				cf.Set(K("c"), 0.015)
+				cf.Set(K("a", "b", "c"), 0.823)
+				return cf
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mfns := tc.mfns()
+			expectedMfn := tc.expectedMfn()
+			cf := CombineYager(mfns...)
+			for _, possibility := range cf.Possibilities() {
+				expectedValue := expectedMfn.Get(possibility)
+				value := cf.Get(possibility)
+				assert.InDelta(expectedValue, value, tolerance)
+			}
+			assert.True(cf.Valid())
+		})
+	}
+}
+
+func TestCombineDuboisPrade(t *testing.T) {
+	const tolerance = 0.0025
+
+	tcs := []struct {
+		name        string
+		mfns        func() []*MassFunction
+		expectedMfn func() *MassFunction
+	}{
+		{
+			name: "traffic light",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("red"), 0.35)
+				mf1.Set(K("yellow"), 0.25)
+				mf1.Set(K("green"), 0.15)
+				mf1.Set(K("red", "yellow"), 0.06)
+				mf1.Set(K("red", "green"), 0.05)
+				mf1.Set(K("yellow", "green"), 0.04)
+				mf1.Set(K("red", "yellow", "green"), 0.1)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("red"), 0.15)
+				mf2.Set(K("yellow"), 0.3)
+				mf2.Set(K("green"), 0.2)
+				mf2.Set(K("red", "yellow"), 0.03)
+				mf2.Set(K("red", "green"), 0.01)
+				mf2.Set(K("yellow", "green"), 0.01)
+				mf2.Set(K("red", "yellow", "green"), 0.3)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("red"), 0.2051)
+				cf.Set(K("yellow"), 0.2218)
+				cf.Set(K("green"), 0.1169)
+				cf.Set(K("red", "yellow"), 0.1653)
+				cf.Set(K("red", "green"), 0.109)
+				cf.Set(K("yellow", "green"), 0.1084)
+				cf.Set(K("red", "yellow", "green"), 0.0735)
+				return cf
+			},
+		},
+		{
+			name: "block decision high conflict",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("allow"), 1.0)
+				mf1.Set(K("deny"), 0.0)
+				mf1.Set(K("allow", "deny"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K(), 0.0)
+				mf2.Set(K("allow"), 0.0)
+				mf2.Set(K("deny"), 1.0)
+				mf2.Set(K("allow", "deny"), 0.0)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K(), 0.0)
+				cf.Set(K("allow"), 0.0)
+				cf.Set(K("deny"), 0.0)
+				cf.Set(K("allow", "deny"), 1.0)
+				return cf
+			},
+		},
+		{
+			name: "zadeh paradox",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("meningitis"), 0.99)
+				mf1.Set(K("tumor"), 0.01)
+				mf1.Set(K("concussion"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("meningitis"), 0.0)
+				mf2.Set(K("tumor"), 0.01)
+				mf2.Set(K("concussion"), 0.99)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("tumor"), 0.0001)
+				cf.Set(K("meningitis", "concussion"), 0.9801)
+				cf.Set(K("meningitis", "tumor"), 0.0099)
+				cf.Set(K("tumor", "concussion"), 0.0099)
+				return cf
+			},
+		},
+		{
+			// https://www.mdpi.com/1424-8220/18/5/1487/pdf
+			name: "multi-sensor target recognition system 3x evidence",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("a"), 0.30)
+				mf1.Set(K("b"), 0.20)
+				mf1.Set(K("c"), 0.10)
+				mf1.Set(K("a", "b", "c"), 0.40)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.00)
+				mf2.Set(K("b"), 0.90)
+				mf2.Set(K("c"), 0.10)
+				mf2.Set(K("a", "b", "c"), 0.00)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.60)
+				mf3.Set(K("b"), 0.10)
+				mf3.Set(K("c"), 0.10)
+				mf3.Set(K("a", "b", "c"), 0.20)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				// Folding pairwise would instead give a = 0.18, b = 0.2, ...
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.0)
+				cf.Set(K("b"), 0.162)
+				cf.Set(K("c"), 0.015)
+				cf.Set(K("a", "b"), 0.297)
+				cf.Set(K("a", "c"), 0.027)
+				cf.Set(K("b", "c"), 0.041)
+				cf.Set(K("a", "b", "c"), 0.458)
+				return cf
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mfns := tc.mfns()
+			expectedMfn := tc.expectedMfn()
+			cf := CombineDuboisPrade(mfns...)
+			for _, possibility := range cf.Possibilities() {
+				expectedValue := expectedMfn.Get(possibility)
+				value := cf.Get(possibility)
+				assert.InDelta(expectedValue, value, tolerance)
+			}
+			assert.True(cf.Valid())
+		})
+	}
+}
+
+func TestCombinePCR5(t *testing.T) {
+	const tolerance = 0.0025
+
+	tcs := []struct {
+		name        string
+		mfns        func() []*MassFunction
+		expectedMfn func() *MassFunction
+	}{
+		{
+			// Smarandache and Dezert, Information Fusion Based on New
+			// Proportional Conflict Redistribution Rules
+			name: "dsmt example",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("a"), 0.6)
+				mf1.Set(K("b"), 0.3)
+				mf1.Set(K("a", "b"), 0.1)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.2)
+				mf2.Set(K("b"), 0.3)
+				mf2.Set(K("a", "b"), 0.5)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.584)
+				cf.Set(K("b"), 0.366)
+				cf.Set(K("a", "b"), 0.05)
+				return cf
+			},
+		},
+		{
+			name: "zadeh paradox",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("meningitis"), 0.99)
+				mf1.Set(K("tumor"), 0.01)
+				mf1.Set(K("concussion"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("meningitis"), 0.0)
+				mf2.Set(K("tumor"), 0.01)
+				mf2.Set(K("concussion"), 0.99)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("meningitis"), 0.49985)
+				cf.Set(K("tumor"), 0.0003)
+				cf.Set(K("concussion"), 0.49985)
+				return cf
+			},
+		},
+		{
+			// https://www.mdpi.com/1424-8220/18/5/1487/pdf
+			name: "multi-sensor target recognition system 3x evidence",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("a"), 0.30)
+				mf1.Set(K("b"), 0.20)
+				mf1.Set(K("c"), 0.10)
+				mf1.Set(K("a", "b", "c"), 0.40)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.00)
+				mf2.Set(K("b"), 0.90)
+				mf2.Set(K("c"), 0.10)
+				mf2.Set(K("a", "b", "c"), 0.00)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.60)
+				mf3.Set(K("b"), 0.10)
+				mf3.Set(K("c"), 0.10)
+				mf3.Set(K("a", "b", "c"), 0.20)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.32932)
+				cf.Set(K("b"), 0.62719)
+				cf.Set(K("c"), 0.04348)
+				cf.Set(K("a", "b", "c"), 0.0)
+				return cf
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mfns := tc.mfns()
+			expectedMfn := tc.expectedMfn()
+			cf := CombinePCR5(mfns...)
+			for _, possibility := range cf.Possibilities() {
+				expectedValue := expectedMfn.Get(possibility)
+				value := cf.Get(possibility)
+				assert.InDelta(expectedValue, value, tolerance)
+			}
+			assert.True(cf.Valid())
+		})
+	}
+}
+
+func TestCombinePCR6(t *testing.T) {
+	const tolerance = 0.0025
+
+	tcs := []struct {
+		name        string
+		mfns        func() []*MassFunction
+		expectedMfn func() *MassFunction
+	}{
+		{
+			// Smarandache and Dezert, Information Fusion Based on New
+			// Proportional Conflict Redistribution Rules
+			name: "dsmt example",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("a"), 0.6)
+				mf1.Set(K("b"), 0.3)
+				mf1.Set(K("a", "b"), 0.1)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.2)
+				mf2.Set(K("b"), 0.3)
+				mf2.Set(K("a", "b"), 0.5)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.584)
+				cf.Set(K("b"), 0.366)
+				cf.Set(K("a", "b"), 0.05)
+				return cf
+			},
+		},
+		{
+			name: "zadeh paradox",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("meningitis"), 0.99)
+				mf1.Set(K("tumor"), 0.01)
+				mf1.Set(K("concussion"), 0.0)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("meningitis"), 0.0)
+				mf2.Set(K("tumor"), 0.01)
+				mf2.Set(K("concussion"), 0.99)
+
+				return []*MassFunction{mf1, mf2}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("meningitis"), 0.49985)
+				cf.Set(K("tumor"), 0.0003)
+				cf.Set(K("concussion"), 0.49985)
+				return cf
+			},
+		},
+		{
+			// https://www.mdpi.com/1424-8220/18/5/1487/pdf
+			name: "multi-sensor target recognition system 3x evidence",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K(), 0.0)
+				mf1.Set(K("a"), 0.30)
+				mf1.Set(K("b"), 0.20)
+				mf1.Set(K("c"), 0.10)
+				mf1.Set(K("a", "b", "c"), 0.40)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.00)
+				mf2.Set(K("b"), 0.90)
+				mf2.Set(K("c"), 0.10)
+				mf2.Set(K("a", "b", "c"), 0.00)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.60)
+				mf3.Set(K("b"), 0.10)
+				mf3.Set(K("c"), 0.10)
+				mf3.Set(K("a", "b", "c"), 0.20)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.28)
+				cf.Set(K("b"), 0.5968)
+				cf.Set(K("c"), 0.04173)
+				cf.Set(K("a", "b", "c"), 0.08147)
+				return cf
+			},
+		},
+		{
+			name: "three bayesian sources",
+			mfns: func() []*MassFunction {
+				mf1 := &MassFunction{}
+				mf1.Set(K("a"), 0.6)
+				mf1.Set(K("b"), 0.4)
+
+				mf2 := &MassFunction{}
+				mf2.Set(K("a"), 0.6)
+				mf2.Set(K("b"), 0.4)
+
+				mf3 := &MassFunction{}
+				mf3.Set(K("a"), 0.4)
+				mf3.Set(K("b"), 0.6)
+
+				return []*MassFunction{mf1, mf2, mf3}
+			},
+			expectedMfn: func() *MassFunction {
+				cf := &MassFunction{}
+				cf.Set(K("a"), 0.55448)
+				cf.Set(K("b"), 0.44552)
+				return cf
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mfns := tc.mfns()
+			expectedMfn := tc.expectedMfn()
+			cf := CombinePCR6(mfns...)
+			for _, possibility := range cf.Possibilities() {
+				expectedValue := expectedMfn.Get(possibility)
+				value := cf.Get(possibility)
+				assert.InDelta(expectedValue, value, tolerance)
+			}
+			assert.True(cf.Valid())
+		})
+	}
+}
+
+func BenchmarkCombineConjunctive(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
+	mf2.Set(K("c"), 0.10)
+	mf2.Set(K("a", "b", "c"), 0.00)
+
+	mf3 := &MassFunction{}
+	mf3.Set(K("a"), 0.60)
+	mf3.Set(K("b"), 0.10)
+	mf3.Set(K("c"), 0.10)
+	mf3.Set(K("a", "b", "c"), 0.20)
+
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 0.70)
+	mf4.Set(K("b"), 0.10)
+	mf4.Set(K("c"), 0.10)
+	mf4.Set(K("a", "b", "c"), 0.10)
+
+	mf5 := &MassFunction{}
+	mf5.Set(K("a"), 0.70)
+	mf5.Set(K("b"), 0.10)
+	mf5.Set(K("c"), 0.10)
+	mf5.Set(K("a", "b", "c"), 0.10)
+
+	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+
+	for n := 0; n < b.N; n++ {
+		CombineConjunctive(mfns...)
+	}
+}
+
+func BenchmarkCombineDisjunctive(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
 	mf2.Set(K("c"), 0.10)
 	mf2.Set(K("a", "b", "c"), 0.00)
 
@@ -797,3 +1655,159 @@ func BenchmarkCombineMurphyAverage(b *testing.B) {
 		CombineMurphyAverage(mfns...)
 	}
 }
+
+func BenchmarkCombineYager(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
+	mf2.Set(K("c"), 0.10)
+	mf2.Set(K("a", "b", "c"), 0.00)
+
+	mf3 := &MassFunction{}
+	mf3.Set(K("a"), 0.60)
+	mf3.Set(K("b"), 0.10)
+	mf3.Set(K("c"), 0.10)
+	mf3.Set(K("a", "b", "c"), 0.20)
+
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 0.70)
+	mf4.Set(K("b"), 0.10)
+	mf4.Set(K("c"), 0.10)
+	mf4.Set(K("a", "b", "c"), 0.10)
+
+	mf5 := &MassFunction{}
+	mf5.Set(K("a"), 0.70)
+	mf5.Set(K("b"), 0.10)
+	mf5.Set(K("c"), 0.10)
+	mf5.Set(K("a", "b", "c"), 0.10)
+
+	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+
+	for n := 0; n < b.N; n++ {
+		CombineYager(mfns...)
+	}
+}
+
+func BenchmarkCombineDuboisPrade(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
+	mf2.Set(K("c"), 0.10)
+	mf2.Set(K("a", "b", "c"), 0.00)
+
+	mf3 := &MassFunction{}
+	mf3.Set(K("a"), 0.60)
+	mf3.Set(K("b"), 0.10)
+	mf3.Set(K("c"), 0.10)
+	mf3.Set(K("a", "b", "c"), 0.20)
+
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 0.70)
+	mf4.Set(K("b"), 0.10)
+	mf4.Set(K("c"), 0.10)
+	mf4.Set(K("a", "b", "c"), 0.10)
+
+	mf5 := &MassFunction{}
+	mf5.Set(K("a"), 0.70)
+	mf5.Set(K("b"), 0.10)
+	mf5.Set(K("c"), 0.10)
+	mf5.Set(K("a", "b", "c"), 0.10)
+
+	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+
+	for n := 0; n < b.N; n++ {
+		CombineDuboisPrade(mfns...)
+	}
+}
+
+func BenchmarkCombinePCR5(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
+	mf2.Set(K("c"), 0.10)
+	mf2.Set(K("a", "b", "c"), 0.00)
+
+	mf3 := &MassFunction{}
+	mf3.Set(K("a"), 0.60)
+	mf3.Set(K("b"), 0.10)
+	mf3.Set(K("c"), 0.10)
+	mf3.Set(K("a", "b", "c"), 0.20)
+
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 0.70)
+	mf4.Set(K("b"), 0.10)
+	mf4.Set(K("c"), 0.10)
+	mf4.Set(K("a", "b", "c"), 0.10)
+
+	mf5 := &MassFunction{}
+	mf5.Set(K("a"), 0.70)
+	mf5.Set(K("b"), 0.10)
+	mf5.Set(K("c"), 0.10)
+	mf5.Set(K("a", "b", "c"), 0.10)
+
+	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+
+	for n := 0; n < b.N; n++ {
+		CombinePCR5(mfns...)
+	}
+}
+
+func BenchmarkCombinePCR6(b *testing.B) {
+	mf1 := &MassFunction{}
+	mf1.Set(K(), 0.0)
+	mf1.Set(K("a"), 0.30)
+	mf1.Set(K("b"), 0.20)
+	mf1.Set(K("c"), 0.10)
+	mf1.Set(K("a", "b", "c"), 0.40)
+
+	mf2 := &MassFunction{}
+	mf2.Set(K("a"), 0.00)
+	mf2.Set(K("b"), 0.90)
+	mf2.Set(K("c"), 0.10)
+	mf2.Set(K("a", "b", "c"), 0.00)
+
+	mf3 := &MassFunction{}
+	mf3.Set(K("a"), 0.60)
+	mf3.Set(K("b"), 0.10)
+	mf3.Set(K("c"), 0.10)
+	mf3.Set(K("a", "b", "c"), 0.20)
+
+	mf4 := &MassFunction{}
+	mf4.Set(K("a"), 0.70)
+	mf4.Set(K("b"), 0.10)
+	mf4.Set(K("c"), 0.10)
+	mf4.Set(K("a", "b", "c"), 0.10)
+
+	mf5 := &MassFunction{}
+	mf5.Set(K("a"), 0.70)
+	mf5.Set(K("b"), 0.10)
+	mf5.Set(K("c"), 0.10)
+	mf5.Set(K("a", "b", "c"), 0.10)
+
+	mfns := []*MassFunction{mf1, mf2, mf3, mf4, mf5}
+
+	for n := 0; n < b.N; n++ {
+		CombinePCR6(mfns...)
+	}
+}
@@ -5,6 +5,13 @@ type CommonalityFunction struct {
 	Function
 }
 
+// NewCommonalityFunction creates an empty CommonalityFunction bound to the given frame.
+func NewCommonalityFunction(frame *Frame) *CommonalityFunction {
+	cf := &CommonalityFunction{}
+	cf.bind(frame, true)
+	return cf
+}
+
 // Valid verifies that a given CommonalityFunction meets the defined
 // requirements for one. All probabilities must be in the range 0.0 >= p >= 1.0.
 func (pf *CommonalityFunction) Valid() bool {
@@ -0,0 +1,175 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errImplausible = errors.New("cannot condition on a possibility with zero plausibility")
+	errUnbelieved  = errors.New("cannot condition on a possibility with zero belief")
+)
+
+// conditioningKey looks up the possibility being conditioned on in the
+// function's frame.
+func (f *Function) conditioningKey(key functionKey) (bk bitKey, err error) {
+	bk, ok := f.frame.key(key)
+	if !ok {
+		return bk, fmt.Errorf("possibility %s is not part of %s", key, f.frame)
+	}
+	return bk, nil
+}
+
+// Condition returns a new MassFunction updated according to Dempster's rule of
+// conditioning on hard evidence that the truth lies within the given
+// possibility. The mass of each focal set is transferred to its intersection
+// with the possibility, and the result is normalized by the possibility's
+// plausibility. This is equivalent to combining the MassFunction with a
+// categorical MassFunction on the possibility using CombineConjunctive.
+// Returns an error if the possibility isn't part of the frame or if its
+// plausibility is zero.
+func (mf *MassFunction) Condition(key functionKey) (cmf *MassFunction, err error) {
+	mf.mux.Lock()
+	defer mf.mux.Unlock()
+	mf.init()
+	b, err := mf.conditioningKey(key)
+	if err != nil {
+		return nil, err
+	}
+	masses := make(map[bitKey]float64)
+	pl := 0.0
+	for _, f := range mf.focalSetsUnsafe(mf.frame) {
+		if a := f.key.Intersect(b); !a.IsEmpty() {
+			masses[a] += f.value
+			pl += f.value
+		}
+	}
+	if floatFixed(pl, 5) == 0.0 {
+		return nil, errImplausible
+	}
+	cmf = &MassFunction{}
+	cmf.bind(mf.frame, mf.bound)
+	for p, v := range masses {
+		cmf.setUnsafe(p, v/pl)
+	}
+	cmf.setUnsafe(bitKey{}, 0.0)
+	return cmf, nil
+}
+
+// ConditionGeometric returns a new MassFunction updated according to geometric
+// conditioning on hard evidence that the truth lies within the given
+// possibility. Only the focal sets that are contained in the possibility are
+// kept, and they are normalized by the possibility's belief. Unlike Dempster's
+// rule of conditioning, mass on focal sets that merely overlap the possibility
+// is discarded rather than narrowed. Returns an error if the possibility isn't
+// part of the frame or if its belief is zero.
+func (mf *MassFunction) ConditionGeometric(key functionKey) (cmf *MassFunction, err error) {
+	mf.mux.Lock()
+	defer mf.mux.Unlock()
+	mf.init()
+	b, err := mf.conditioningKey(key)
+	if err != nil {
+		return nil, err
+	}
+	masses := make(map[bitKey]float64)
+	bel := 0.0
+	for _, f := range mf.focalSetsUnsafe(mf.frame) {
+		if !f.key.IsEmpty() && f.key.IsSubset(b) {
+			masses[f.key] = f.value
+			bel += f.value
+		}
+	}
+	if floatFixed(bel, 5) == 0.0 {
+		return nil, errUnbelieved
+	}
+	cmf = &MassFunction{}
+	cmf.bind(mf.frame, mf.bound)
+	for p, v := range masses {
+		cmf.setUnsafe(p, v/bel)
+	}
+	cmf.setUnsafe(bitKey{}, 0.0)
+	return cmf, nil
+}
+
+// faginHalpern conditions a dense vector of beliefs on the possibility b,
+// returning Bel(A|B) = Bel(A∩B) / (Bel(A∩B) + Pl(Ā∩B)) for every possibility
+// A. The belief in b must not be zero.
+func faginHalpern(bel []float64, b int) []float64 {
+	universe := len(bel) - 1
+	conditioned := make([]float64, len(bel))
+	for a := range bel {
+		in := a & b
+		if in == 0 {
+			continue
+		}
+		// Pl(Ā∩B) is 1 - Bel of its complement
+		out := 1.0 - bel[universe&^(b&^a)]
+		conditioned[a] = bel[in] / (bel[in] + out)
+	}
+	return conditioned
+}
+
+// Condition returns a new BeliefFunction updated according to Fagin and
+// Halpern's rule of conditioning on hard evidence that the truth lies within
+// the given possibility. The result is the lower envelope of the conditional
+// probabilities of every probability distribution consistent with the
+// BeliefFunction, Bel(A|B) = Bel(A∩B) / (Bel(A∩B) + Pl(Ā∩B)), and is always
+// less committed than Dempster's rule of conditioning. Returns an error if the
+// possibility isn't part of the frame, if its plausibility is zero, if its
+// belief is zero, or if the frame is too large to enumerate.
+func (bf *BeliefFunction) Condition(key functionKey) (cbf *BeliefFunction, err error) {
+	bf.mux.Lock()
+	defer bf.mux.Unlock()
+	bf.init()
+	b, err := bf.conditioningKey(key)
+	if err != nil {
+		return nil, err
+	}
+	bel, err := bf.denseUnsafe()
+	if err != nil {
+		return nil, err
+	}
+	if floatFixed(1.0-bel[uint64(len(bel)-1)&^b.lo], 5) == 0.0 {
+		return nil, errImplausible
+	}
+	if floatFixed(bel[b.lo], 5) == 0.0 {
+		return nil, errUnbelieved
+	}
+	cbf = &BeliefFunction{}
+	cbf.bind(bf.frame, bf.bound)
+	cbf.setDenseUnsafe(faginHalpern(bel, int(b.lo)), false)
+	return cbf, nil
+}
+
+// Condition returns a new PlausibilityFunction updated according to Fagin and
+// Halpern's rule of conditioning on hard evidence that the truth lies within
+// the given possibility. The result is the upper envelope of the conditional
+// probabilities of every probability distribution consistent with the
+// PlausibilityFunction, Pl(A|B) = Pl(A∩B) / (Pl(A∩B) + Bel(Ā∩B)). Returns an
+// error if the possibility isn't part of the frame, if its plausibility is
+// zero, if its belief is zero, or if the frame is too large to enumerate.
+func (pf *PlausibilityFunction) Condition(key functionKey) (cpf *PlausibilityFunction, err error) {
+	pf.mux.Lock()
+	defer pf.mux.Unlock()
+	pf.init()
+	b, err := pf.conditioningKey(key)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := pf.denseUnsafe()
+	if err != nil {
+		return nil, err
+	}
+	bel := dualize(pl)
+	if floatFixed(pf.value(b), 5) == 0.0 {
+		return nil, errImplausible
+	}
+	if floatFixed(bel[b.lo], 5) == 0.0 {
+		return nil, errUnbelieved
+	}
+	cpf = &PlausibilityFunction{}
+	cpf.bind(pf.frame, pf.bound)
+	// Pl(A|B) = 1 - Bel(Ā|B)
+	cpf.setDenseUnsafe(dualize(faginHalpern(bel, int(b.lo))), false)
+	return cpf, nil
+}
@@ -0,0 +1,122 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCondition(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	cmf, err := mf.Condition(K("red", "yellow"))
+	assert.Nil(err)
+	assert.True(cmf.Frame() == mf.Frame())
+	assert.InDelta(0.40/0.85, cmf.Get(K("red")), tolerance)
+	assert.InDelta(0.29/0.85, cmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.16/0.85, cmf.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.0, cmf.Get(K("green")), tolerance)
+	assert.True(cmf.Valid())
+
+	// Dempster conditioning is combination with a categorical MassFunction
+	categorical := &MassFunction{}
+	categorical.Set(K("red", "yellow"), 1.0)
+	ccf := CombineConjunctive(mf, categorical)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(ccf.Get(p), cmf.Get(p), tolerance, p.String())
+	}
+}
+
+func TestConditionGeometric(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	cmf, err := mf.ConditionGeometric(K("red", "yellow"))
+	assert.Nil(err)
+	assert.InDelta(0.35/0.66, cmf.Get(K("red")), tolerance)
+	assert.InDelta(0.25/0.66, cmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.06/0.66, cmf.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.0, cmf.Get(K("red", "yellow", "green")), tolerance)
+	assert.True(cmf.Valid())
+}
+
+func TestConditionFaginHalpern(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	cbf, err := mf.Belief().Condition(K("red", "yellow"))
+	assert.Nil(err)
+	cpf, err := mf.Plausibility().Condition(K("red", "yellow"))
+	assert.Nil(err)
+
+	tcs := []struct {
+		key functionKey
+		bel float64
+		pl  float64
+	}{
+		{key: K(), bel: 0.0, pl: 0.0},
+		{key: K("red"), bel: 0.4375, pl: 0.691358},
+		{key: K("yellow"), bel: 0.308642, pl: 0.5625},
+		{key: K("green"), bel: 0.0, pl: 0.0},
+		{key: K("red", "green"), bel: 0.4375, pl: 0.691358},
+		{key: K("red", "yellow"), bel: 1.0, pl: 1.0},
+		{key: K("red", "yellow", "green"), bel: 1.0, pl: 1.0},
+	}
+	for _, tc := range tcs {
+		assert.InDelta(tc.bel, cbf.Get(tc.key), tolerance, tc.key.String())
+		assert.InDelta(tc.pl, cpf.Get(tc.key), tolerance, tc.key.String())
+	}
+
+	// Fagin-Halpern conditioning is never more committed than Dempster's
+	cmf, _ := mf.Condition(K("red", "yellow"))
+	dbf := cmf.Belief()
+	for _, p := range mf.Powerset() {
+		assert.True(cbf.Get(p) <= dbf.Get(p)+tolerance, p.String())
+	}
+	assert.True(cbf.Valid())
+	assert.True(cpf.Valid())
+}
+
+func TestConditionErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("a", "b", "c")
+	mf := NewMassFunction(fr)
+	mf.Set(K("a"), 0.6)
+	mf.Set(K("a", "b"), 0.4)
+
+	_, err := mf.Condition(K("c"))
+	assert.Equal(errImplausible, err)
+	_, err = mf.Condition(K("d"))
+	assert.NotNil(err)
+	_, err = mf.ConditionGeometric(K("b", "c"))
+	assert.Equal(errUnbelieved, err)
+	_, err = mf.Belief().Condition(K("c"))
+	assert.Equal(errImplausible, err)
+	_, err = mf.Belief().Condition(K("b", "c"))
+	assert.Equal(errUnbelieved, err)
+	_, err = mf.Plausibility().Condition(K("c"))
+	assert.Equal(errImplausible, err)
+	_, err = mf.Plausibility().Condition(K("d"))
+	assert.NotNil(err)
+
+	// Plausible but unbelieved possibilities can still be conditioned on
+	// using Dempster's rule
+	cmf, err := mf.Condition(K("b", "c"))
+	assert.Nil(err)
+	assert.InDelta(1.0, cmf.Get(K("b")), 0.00001)
+
+	large, _ := largeFrame()
+	bf := NewBeliefFunction(large)
+	bf.Set(K("h0"), 1.0)
+	_, err = bf.Condition(K("h0"))
+	assert.Equal(errFrameTooLarge, err)
+	pf := NewPlausibilityFunction(large)
+	pf.Set(K("h0"), 1.0)
+	_, err = pf.Condition(K("h0"))
+	assert.Equal(errFrameTooLarge, err)
+}
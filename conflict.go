@@ -0,0 +1,86 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errNoMassFunctions = errors.New("no mass functions provided")
+
+// Conflict returns Dempster's degree of conflict K between two or more
+// MassFunctions, the mass that their unnormalized conjunctive combination
+// assigns to the empty set. This is the mass that CombineConjunctive discards
+// when it normalizes the combination. A conflict of 1.0 means the
+// MassFunctions are entirely contradictory and can't be combined by
+// Dempster's rule. Returns an error if no MassFunctions are provided or if
+// their frames are incompatible.
+func Conflict(mfns ...*MassFunction) (float64, error) {
+	if len(mfns) == 0 {
+		return 0.0, errNoMassFunctions
+	}
+	frame, _, err := alignFrames(mfns...)
+	if err != nil {
+		return 0.0, err
+	}
+	return conjunctiveMasses(frame, mfns...)[bitKey{}], nil
+}
+
+// WeightOfConflict returns Shafer's weight of conflict between two or more
+// MassFunctions, log(1/(1-K)) where K is their degree of conflict. Unlike K,
+// the weight of conflict is additive: the weight of conflict of a combination
+// is the sum of the weights of conflict of each step that produced it. It is
+// positive infinity for entirely contradictory MassFunctions. Returns an error
+// if no MassFunctions are provided or if their frames are incompatible.
+func WeightOfConflict(mfns ...*MassFunction) (float64, error) {
+	k, err := Conflict(mfns...)
+	if err != nil {
+		return 0.0, err
+	}
+	return -math.Log(1.0 - k), nil
+}
+
+// ConflictMatrix returns the degree of conflict between each pair of
+// MassFunctions, such that matrix[i][j] is the conflict between mfns[i] and
+// mfns[j]. The matrix is symmetric, and its diagonal holds the auto-conflict
+// of degree 2 of each MassFunction. Returns an error if no MassFunctions are
+// provided or if their frames are incompatible.
+func ConflictMatrix(mfns ...*MassFunction) ([][]float64, error) {
+	if len(mfns) == 0 {
+		return nil, errNoMassFunctions
+	}
+	frame, _, err := alignFrames(mfns...)
+	if err != nil {
+		return nil, err
+	}
+	matrix := make([][]float64, len(mfns))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(mfns))
+	}
+	for i := range mfns {
+		for j := i; j < len(mfns); j++ {
+			k := conjunctiveMasses(frame, mfns[i], mfns[j])[bitKey{}]
+			matrix[i][j] = k
+			matrix[j][i] = k
+		}
+	}
+	return matrix, nil
+}
+
+// AutoConflict returns Osswald and Martin's auto-conflict of the given degree,
+// the degree of conflict between degree copies of the MassFunction. It
+// measures how internally contradictory a single body of evidence is, and is
+// non-decreasing in the degree. An auto-conflict of degree 1 is simply the mass
+// already assigned to the empty set. Returns an error if the degree is less
+// than 1.
+func (mf *MassFunction) AutoConflict(degree int) (float64, error) {
+	if degree < 1 {
+		return 0.0, fmt.Errorf("degree must be at least 1, got %d", degree)
+	}
+	frame, _ := mf.frameInfo()
+	mfns := make([]*MassFunction, degree)
+	for i := range mfns {
+		mfns[i] = mf
+	}
+	return conjunctiveMasses(frame, mfns...)[bitKey{}], nil
+}
@@ -0,0 +1,144 @@
+package evidence
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func conflictingReports() (*MassFunction, *MassFunction, *MassFunction) {
+	fr, _ := NewFrame("a", "b", "c")
+	mf1 := NewMassFunction(fr)
+	mf1.Set(K("a"), 0.6)
+	mf1.Set(K("a", "b"), 0.4)
+	mf2 := NewMassFunction(fr)
+	mf2.Set(K("b"), 0.7)
+	mf2.Set(K("a", "b", "c"), 0.3)
+	mf3 := NewMassFunction(fr)
+	mf3.Set(K("c"), 0.5)
+	mf3.Set(K("a", "b", "c"), 0.5)
+	return mf1, mf2, mf3
+}
+
+func TestConflict(t *testing.T) {
+	const tolerance = 0.00001
+	mf1, mf2, mf3 := conflictingReports()
+
+	tcs := []struct {
+		name     string
+		mfns     []*MassFunction
+		conflict float64
+	}{
+		{
+			name:     "pair",
+			mfns:     []*MassFunction{mf1, mf2},
+			conflict: 0.42,
+		},
+		{
+			name:     "three sources",
+			mfns:     []*MassFunction{mf1, mf2, mf3},
+			conflict: 0.71,
+		},
+		{
+			name:     "no conflict",
+			mfns:     []*MassFunction{mf1, mf1},
+			conflict: 0.0,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			k, err := Conflict(tc.mfns...)
+			assert.Nil(err)
+			assert.InDelta(tc.conflict, k, tolerance)
+			woc, err := WeightOfConflict(tc.mfns...)
+			assert.Nil(err)
+			assert.InDelta(math.Log(1.0/(1.0-tc.conflict)), woc, tolerance)
+			// The conflict is what the unnormalized rule leaves on the empty set
+			assert.InDelta(k, CombineConjunctiveUnnormalized(tc.mfns...).Get(K()), tolerance)
+		})
+	}
+}
+
+func TestConflictErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Conflict()
+	assert.NotNil(err)
+	_, err = WeightOfConflict()
+	assert.NotNil(err)
+	_, err = ConflictMatrix()
+	assert.NotNil(err)
+
+	fr, _ := NewFrame("x", "y")
+	other := NewMassFunction(fr)
+	other.Set(K("x"), 1.0)
+	mf1, _, _ := conflictingReports()
+	_, err = Conflict(mf1, other)
+	assert.NotNil(err)
+	_, err = ConflictMatrix(mf1, other)
+	assert.NotNil(err)
+
+	// Entirely contradictory evidence has an infinite weight of conflict
+	mf2 := NewMassFunction(fr)
+	mf2.Set(K("y"), 1.0)
+	woc, err := WeightOfConflict(other, mf2)
+	assert.Nil(err)
+	assert.True(math.IsInf(woc, 1))
+}
+
+func TestWeightOfConflictIsAdditive(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf1, mf2, mf3 := conflictingReports()
+	total, _ := WeightOfConflict(mf1, mf2, mf3)
+	first, _ := WeightOfConflict(mf1, mf2)
+	second, _ := WeightOfConflict(CombineConjunctive(mf1, mf2), mf3)
+	assert.InDelta(total, first+second, tolerance)
+}
+
+func TestConflictMatrix(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf1, mf2, mf3 := conflictingReports()
+	matrix, err := ConflictMatrix(mf1, mf2, mf3)
+	assert.Nil(err)
+	expected := [][]float64{
+		{0.0, 0.42, 0.5},
+		{0.42, 0.0, 0.35},
+		{0.5, 0.35, 0.0},
+	}
+	for i := range expected {
+		assert.InDeltaSlice(expected[i], matrix[i], tolerance)
+	}
+}
+
+func TestAutoConflict(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := &MassFunction{}
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("b"), 0.3)
+	mf.Set(K("a", "b"), 0.2)
+	for degree, expected := range []float64{0.0, 0.3, 0.54, 0.699} {
+		k, err := mf.AutoConflict(degree + 1)
+		assert.Nil(err)
+		assert.InDelta(expected, k, tolerance)
+	}
+
+	// Consonant evidence never conflicts with itself
+	mf1, _, _ := conflictingReports()
+	k, err := mf1.AutoConflict(5)
+	assert.Nil(err)
+	assert.InDelta(0.0, k, tolerance)
+
+	_, err = mf.AutoConflict(0)
+	assert.NotNil(err)
+	_, err = mf.AutoConflict(-1)
+	assert.NotNil(err)
+}
@@ -0,0 +1,175 @@
+package evidence
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A MassFunction is consonant when its focal sets are nested, each one
+// containing the last. Consonant MassFunctions are the possibility measures of
+// possibility theory: the plausibility of a possibility is the largest
+// plausibility of any of its hypotheses, and the MassFunction is entirely
+// determined by the plausibilities of its hypotheses alone, its contour
+// function.
+
+// decreasing returns the positions of a frame's hypotheses ordered by
+// decreasing value, with ties kept in frame order.
+func decreasing(values []float64) []int {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return values[order[a]] > values[order[b]]
+	})
+	return order
+}
+
+// nested returns the nested possibilities made up of the first one, two, and
+// so on up to all of the hypotheses in order.
+func nested(order []int) []bitKey {
+	bks := make([]bitKey, len(order))
+	bk := bitKey{}
+	for k, i := range order {
+		bk = bk.With(i)
+		bks[k] = bk
+	}
+	return bks
+}
+
+// consonant returns the consonant MassFunction over the frame whose contour
+// function is given by the possibility of each hypothesis, by position. If no
+// hypothesis is fully possible, the difference is assigned to the empty set and
+// the MassFunction follows the open-world assumption.
+func consonant(frame *Frame, bound bool, possibility []float64) *MassFunction {
+	cmf := &MassFunction{}
+	cmf.bind(frame, bound)
+	order := decreasing(possibility)
+	for k, bk := range nested(order) {
+		next := 0.0
+		if k+1 < len(order) {
+			next = possibility[order[k+1]]
+		}
+		cmf.setUnsafe(bk, possibility[order[k]]-next)
+	}
+	top := 0.0
+	if len(order) > 0 {
+		top = possibility[order[0]]
+	}
+	if floatFixed(top, 5) < 1.0 {
+		cmf.setUnsafe(bitKey{}, 1.0-top)
+		cmf.openWorld = true
+	}
+	return cmf
+}
+
+// NewConsonantMassFunction creates the consonant MassFunction bound to the
+// given frame that corresponds to a possibility distribution, assigning to each
+// level cut of the distribution the difference between its possibility and the
+// next lower one. Hypotheses of the frame that the distribution leaves out are
+// taken to be impossible. A subnormal distribution, where no hypothesis is
+// fully possible, leaves the remainder on the empty set, and the new
+// MassFunction then follows the open-world assumption. Returns an error if a
+// hypothesis isn't part of the frame or if a possibility is outside the range
+// 0.0 >= π >= 1.0.
+func NewConsonantMassFunction(frame *Frame,
+	distribution map[string]float64) (*MassFunction, error) {
+	possibility := make([]float64, frame.Len())
+	for h, v := range distribution {
+		if !frame.Contains(h) {
+			return nil, fmt.Errorf("hypothesis %q is not part of %s", h, frame)
+		}
+		if v < 0.0 || v > 1.0 {
+			return nil, fmt.Errorf("possibility of %q out of range (%v)", h, v)
+		}
+		possibility[frame.Index(h)] = v
+	}
+	return consonant(frame, true, possibility), nil
+}
+
+// contour returns the plausibility of each of the frame's hypotheses, by
+// position.
+func (mf *MassFunction) contour(frame *Frame) []float64 {
+	pl := make([]float64, frame.Len())
+	for i, v := range singletonPlausibilities(mf.nonEmptyFocalSets()) {
+		pl[i] = v
+	}
+	return pl
+}
+
+// ContourFunction returns the MassFunction's contour function, the
+// plausibility of each hypothesis of its frame. This is the possibility
+// distribution of a consonant MassFunction, from which
+// NewConsonantMassFunction recreates it.
+func (mf *MassFunction) ContourFunction() map[string]float64 {
+	frame, _ := mf.frameInfo()
+	distribution := make(map[string]float64, frame.Len())
+	for i, v := range mf.contour(frame) {
+		distribution[frame.hypotheses[i]] = v
+	}
+	return distribution
+}
+
+// IsConsonant returns true if the MassFunction's non-empty focal sets are
+// nested.
+func (mf *MassFunction) IsConsonant() bool {
+	fs := mf.nonEmptyFocalSets()
+	sort.Slice(fs, func(i, j int) bool {
+		return fs[i].key.Len() < fs[j].key.Len()
+	})
+	for i := 1; i < len(fs); i++ {
+		if !fs[i-1].key.IsSubset(fs[i].key) {
+			return false
+		}
+	}
+	return true
+}
+
+// OuterConsonantApproximation returns a new consonant MassFunction that is less
+// committed than this one, so that its plausibility of every possibility is at
+// least as high. The hypotheses are ordered by decreasing plausibility, and the
+// mass of each focal set is moved to the smallest of the nested possibilities
+// made up of the first hypotheses in that order that contains it, following
+// Dubois and Prade. Mass assigned to the empty set is left there. A consonant
+// MassFunction is returned unchanged.
+func (mf *MassFunction) OuterConsonantApproximation() *MassFunction {
+	frame, bound := mf.frameInfo()
+	order := decreasing(mf.contour(frame))
+	rank := make([]int, len(order))
+	for k, i := range order {
+		rank[i] = k
+	}
+	bks := nested(order)
+	masses := make(map[bitKey]float64)
+	for _, f := range mf.focalSets(frame) {
+		if f.key.IsEmpty() {
+			masses[f.key] += f.value
+			continue
+		}
+		last := 0
+		for _, i := range f.key.Elements() {
+			if rank[i] > last {
+				last = rank[i]
+			}
+		}
+		masses[bks[last]] += f.value
+	}
+	omf := &MassFunction{openWorld: mf.OpenWorld()}
+	omf.bind(frame, bound)
+	for p, v := range masses {
+		omf.setUnsafe(p, v)
+	}
+	return omf
+}
+
+// InnerConsonantApproximation returns a new consonant MassFunction that is more
+// committed than this one, so that its plausibility of every possibility is at
+// most as high. It is the consonant MassFunction with the same contour
+// function, whose plausibility of a possibility is the largest plausibility of
+// any of its hypotheses. If no hypothesis is fully plausible, the remainder is
+// assigned to the empty set and the new MassFunction follows the open-world
+// assumption. A consonant MassFunction is returned unchanged.
+func (mf *MassFunction) InnerConsonantApproximation() *MassFunction {
+	frame, bound := mf.frameInfo()
+	return consonant(frame, bound, mf.contour(frame))
+}
@@ -0,0 +1,115 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConsonantMassFunction(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	frame, _ := NewFrame("a", "b", "c", "d")
+	mf, err := NewConsonantMassFunction(frame, map[string]float64{
+		"a": 1.0, "b": 0.7, "c": 0.2,
+	})
+	assert.Nil(err)
+	assert.True(mf.Frame() == frame)
+	assert.InDelta(0.3, mf.Get(K("a")), tolerance)
+	assert.InDelta(0.5, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.2, mf.Get(K("a", "b", "c")), tolerance)
+	assert.InDelta(0.0, mf.Get(K("a", "b", "c", "d")), tolerance)
+	assert.False(mf.OpenWorld())
+	assert.True(mf.Valid())
+	assert.True(mf.IsConsonant())
+
+	// The contour function recovers the possibility distribution
+	contour := mf.ContourFunction()
+	assert.Len(contour, 4)
+	assert.InDelta(1.0, contour["a"], tolerance)
+	assert.InDelta(0.7, contour["b"], tolerance)
+	assert.InDelta(0.2, contour["c"], tolerance)
+	assert.InDelta(0.0, contour["d"], tolerance)
+
+	// A subnormal distribution leaves the remainder on the empty set
+	mf, err = NewConsonantMassFunction(frame, map[string]float64{
+		"a": 0.8, "b": 0.5,
+	})
+	assert.Nil(err)
+	assert.InDelta(0.3, mf.Get(K("a")), tolerance)
+	assert.InDelta(0.5, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.2, mf.Get(K()), tolerance)
+	assert.True(mf.OpenWorld())
+	assert.True(mf.Valid())
+
+	_, err = NewConsonantMassFunction(frame, map[string]float64{"e": 1.0})
+	assert.NotNil(err)
+	_, err = NewConsonantMassFunction(frame, map[string]float64{"a": 1.5})
+	assert.NotNil(err)
+}
+
+func TestIsConsonant(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(trafficLight().IsConsonant())
+
+	mf := &MassFunction{}
+	mf.Set(K("a", "b", "c"), 0.2)
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("a", "b"), 0.3)
+	assert.True(mf.IsConsonant())
+
+	mf.Set(K("b", "c"), 0.1)
+	assert.False(mf.IsConsonant())
+}
+
+func TestConsonantApproximations(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+
+	outer := mf.OuterConsonantApproximation()
+	assert.True(outer.Frame() == mf.Frame())
+	assert.True(outer.IsConsonant())
+	assert.InDelta(0.35, outer.Get(K("red")), tolerance)
+	assert.InDelta(0.31, outer.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.34, outer.Get(K("red", "yellow", "green")), tolerance)
+	assert.False(outer.OpenWorld())
+	assert.True(outer.Valid())
+
+	inner := mf.InnerConsonantApproximation()
+	assert.True(inner.Frame() == mf.Frame())
+	assert.True(inner.IsConsonant())
+	assert.InDelta(0.11, inner.Get(K("red")), tolerance)
+	assert.InDelta(0.11, inner.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.34, inner.Get(K("red", "yellow", "green")), tolerance)
+	assert.InDelta(0.44, inner.Get(K()), tolerance)
+	assert.True(inner.OpenWorld())
+	assert.True(inner.Valid())
+
+	// The outer approximation is never more plausible than the original, and
+	// the inner approximation never less
+	pl := mf.Plausibility()
+	outerPl := outer.Plausibility()
+	innerPl := inner.Plausibility()
+	for _, p := range mf.Powerset() {
+		assert.True(outerPl.Get(p) >= pl.Get(p)-tolerance, p.String())
+		assert.True(innerPl.Get(p) <= pl.Get(p)+tolerance, p.String())
+	}
+
+	// Both approximations of a consonant MassFunction are the MassFunction
+	// itself
+	frame, _ := NewFrame("a", "b", "c")
+	cmf, _ := NewConsonantMassFunction(frame, map[string]float64{
+		"a": 0.4, "b": 1.0, "c": 0.9,
+	})
+	for _, amf := range []*MassFunction{
+		cmf.OuterConsonantApproximation(), cmf.InnerConsonantApproximation(),
+	} {
+		for _, p := range cmf.Powerset() {
+			assert.InDelta(cmf.Get(p), amf.Get(p), tolerance, p.String())
+		}
+	}
+}
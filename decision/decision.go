@@ -0,0 +1,285 @@
+// Package decision chooses between acts whose consequences depend on which
+// hypothesis of a frame is true, given evidence about the hypotheses in the
+// form of an evidence.MassFunction.
+package decision
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	evidence "github.com/sporkmonger/go-evidence"
+)
+
+// Utilities is a matrix of the utility of each act when each hypothesis of a
+// frame is true.
+type Utilities struct {
+	frame     *evidence.Frame
+	acts      []string
+	utilities [][]float64
+}
+
+// NewUtilities creates a utility matrix over the given acts and the hypotheses
+// of a frame, where utilities[i][j] is the utility of the i-th act when the
+// j-th hypothesis of the frame is true. Returns an error if there are no acts,
+// if an act is repeated, or if the matrix doesn't have one row per act and one
+// column per hypothesis.
+func NewUtilities(frame *evidence.Frame, acts []string,
+	utilities [][]float64) (*Utilities, error) {
+	if len(acts) == 0 {
+		return nil, errors.New("no acts to choose between")
+	}
+	if len(utilities) != len(acts) {
+		return nil, fmt.Errorf("expected utilities for %d acts, got %d",
+			len(acts), len(utilities))
+	}
+	u := &Utilities{
+		frame:     frame,
+		acts:      make([]string, len(acts)),
+		utilities: make([][]float64, len(acts)),
+	}
+	seen := make(map[string]bool, len(acts))
+	for i, act := range acts {
+		if seen[act] {
+			return nil, fmt.Errorf("duplicate act (%q)", act)
+		}
+		seen[act] = true
+		if len(utilities[i]) != frame.Len() {
+			return nil, fmt.Errorf("expected %d utilities for act %q, got %d",
+				frame.Len(), act, len(utilities[i]))
+		}
+		u.acts[i] = act
+		u.utilities[i] = append([]float64{}, utilities[i]...)
+	}
+	return u, nil
+}
+
+// Frame returns the frame of hypotheses the utilities are defined over.
+func (u *Utilities) Frame() *evidence.Frame {
+	return u.frame
+}
+
+// Acts returns the acts in order.
+func (u *Utilities) Acts() []string {
+	return append([]string{}, u.acts...)
+}
+
+// Utility returns the utility of an act when a hypothesis is true, or NaN if
+// either the act or the hypothesis is unknown.
+func (u *Utilities) Utility(act string, hypothesis string) float64 {
+	j := u.frame.Index(hypothesis)
+	if j < 0 {
+		return math.NaN()
+	}
+	for i, a := range u.acts {
+		if a == act {
+			return u.utilities[i][j]
+		}
+	}
+	return math.NaN()
+}
+
+// An Interval is the range between the lower and upper expected utility of an
+// act, i.e. its expected utility under the least and most favourable
+// probability distributions consistent with the evidence.
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// A Choice is the act chosen by a decision criterion, along with the
+// expected-utility interval of every act and the score that the criterion gave
+// each act.
+type Choice struct {
+	Act       string
+	Intervals map[string]Interval
+	Scores    map[string]float64
+}
+
+// A focal is a focal set of a MassFunction, as the positions of its hypotheses
+// in the frame of the utilities.
+type focal struct {
+	hypotheses []int
+	mass       float64
+}
+
+// focalSets returns the focal sets of a MassFunction over the frame of the
+// utilities. Returns an error if the MassFunction's frame doesn't fit within
+// the utilities' frame or if it assigns mass to the empty set, for which no
+// act has any utility.
+func (u *Utilities) focalSets(mf *evidence.MassFunction) ([]focal, error) {
+	frame := mf.Frame()
+	if mf.Bound() && !frame.Equal(u.frame) || !frame.IsSubset(u.frame) {
+		return nil, fmt.Errorf("mass function over %s does not fit within %s",
+			frame, u.frame)
+	}
+	var fs []focal
+	for _, p := range mf.Possibilities() {
+		mass := mf.Get(p)
+		if mass == 0.0 {
+			continue
+		}
+		elements := p.FocalElements()
+		if len(elements) == 0 {
+			return nil, errors.New("mass assigned to the empty set has no utility")
+		}
+		f := focal{mass: mass}
+		for _, h := range elements {
+			f.hypotheses = append(f.hypotheses, u.frame.Index(string(h)))
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// bounds returns the worst and best utility of an act within a focal set.
+func (u *Utilities) bounds(act int, f focal) (worst float64, best float64) {
+	worst, best = math.Inf(1), math.Inf(-1)
+	for _, j := range f.hypotheses {
+		worst = math.Min(worst, u.utilities[act][j])
+		best = math.Max(best, u.utilities[act][j])
+	}
+	return worst, best
+}
+
+// values returns the utility of an act for each hypothesis.
+func (u *Utilities) values(act int) map[string]float64 {
+	values := make(map[string]float64, u.frame.Len())
+	for j, h := range u.frame.Hypotheses() {
+		values[h] = u.utilities[act][j]
+	}
+	return values
+}
+
+// intervals returns the expected-utility interval of every act, from the lower
+// and upper expectations of its utilities.
+func (u *Utilities) intervals(mf *evidence.MassFunction) (map[string]Interval, error) {
+	intervals := make(map[string]Interval, len(u.acts))
+	for i, act := range u.acts {
+		lower, err := mf.LowerExpectation(u.values(i))
+		if err != nil {
+			return nil, err
+		}
+		upper, err := mf.UpperExpectation(u.values(i))
+		if err != nil {
+			return nil, err
+		}
+		intervals[act] = Interval{Lower: lower, Upper: upper}
+	}
+	return intervals, nil
+}
+
+// choose scores every act and chooses the one with the highest score. Ties go
+// to the act that comes first.
+func (u *Utilities) choose(mf *evidence.MassFunction,
+	score func(act int, fs []focal, interval Interval) float64) (*Choice, error) {
+	fs, err := u.focalSets(mf)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := u.intervals(mf)
+	if err != nil {
+		return nil, err
+	}
+	c := &Choice{
+		Intervals: intervals,
+		Scores:    make(map[string]float64, len(u.acts)),
+	}
+	best := -1
+	for i, act := range u.acts {
+		s := score(i, fs, c.Intervals[act])
+		c.Scores[act] = s
+		if best < 0 || s > c.Scores[u.acts[best]] {
+			best = i
+		}
+	}
+	c.Act = u.acts[best]
+	return c, nil
+}
+
+// MaxBelief chooses the act with the highest lower expected utility, the
+// pessimistic criterion that generalizes maximizing belief: each focal set is
+// assumed to lead to its worst consequence. Returns an error if the
+// MassFunction's frame doesn't fit within the utilities' frame or if it
+// assigns mass to the empty set.
+func MaxBelief(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+	return u.choose(mf, func(act int, fs []focal, interval Interval) float64 {
+		return interval.Lower
+	})
+}
+
+// MaxPlausibility chooses the act with the highest upper expected utility, the
+// optimistic criterion that generalizes maximizing plausibility: each focal set
+// is assumed to lead to its best consequence. Returns an error if the
+// MassFunction's frame doesn't fit within the utilities' frame or if it
+// assigns mass to the empty set.
+func MaxPlausibility(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+	return u.choose(mf, func(act int, fs []focal, interval Interval) float64 {
+		return interval.Upper
+	})
+}
+
+// MaxPignistic chooses the act with the highest expected utility under the
+// MassFunction's pignistic probabilities, as in Smets' Transferable Belief
+// Model, spreading the mass of each focal set evenly across its hypotheses.
+// Returns an error if the MassFunction's frame doesn't fit within the
+// utilities' frame or if it assigns mass to the empty set.
+func MaxPignistic(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+	return u.choose(mf, func(act int, fs []focal, interval Interval) float64 {
+		eu := 0.0
+		for _, f := range fs {
+			for _, j := range f.hypotheses {
+				eu += f.mass / float64(len(f.hypotheses)) * u.utilities[act][j]
+			}
+		}
+		return eu
+	})
+}
+
+// Hurwicz chooses the act with the highest weighted average of its upper and
+// lower expected utility, optimism·upper + (1-optimism)·lower. An optimism of
+// 0.0 is equivalent to MaxBelief and 1.0 to MaxPlausibility. Returns an error
+// if the optimism index is outside the range 0.0 >= o >= 1.0, if the
+// MassFunction's frame doesn't fit within the utilities' frame or if it
+// assigns mass to the empty set.
+func Hurwicz(mf *evidence.MassFunction, u *Utilities, optimism float64) (*Choice, error) {
+	if optimism < 0.0 || optimism > 1.0 {
+		return nil, errors.New("optimism index out of range")
+	}
+	return u.choose(mf, func(act int, fs []focal, interval Interval) float64 {
+		return optimism*interval.Upper + (1.0-optimism)*interval.Lower
+	})
+}
+
+// Jaffray chooses the act with the highest expected utility under Jaffray's
+// criterion, which weights the worst and best consequence within each focal
+// set by a pessimism index that may depend on those consequences, i.e.
+// Σ m(A)·(α·worst + (1-α)·best) where α = pessimism(worst, best). This allows
+// attitudes towards ambiguity that vary with what's at stake. A constant
+// pessimism index is equivalent to Hurwicz with the complementary optimism
+// index. Returns an error if a pessimism index is outside the range
+// 0.0 >= p >= 1.0, if the MassFunction's frame doesn't fit within the
+// utilities' frame or if it assigns mass to the empty set.
+func Jaffray(mf *evidence.MassFunction, u *Utilities,
+	pessimism func(worst float64, best float64) float64) (*Choice, error) {
+	outOfRange := false
+	c, err := u.choose(mf, func(act int, fs []focal, interval Interval) float64 {
+		eu := 0.0
+		for _, f := range fs {
+			worst, best := u.bounds(act, f)
+			alpha := pessimism(worst, best)
+			if alpha < 0.0 || alpha > 1.0 {
+				outOfRange = true
+			}
+			eu += f.mass * (alpha*worst + (1.0-alpha)*best)
+		}
+		return eu
+	})
+	if err != nil {
+		return nil, err
+	}
+	if outOfRange {
+		return nil, errors.New("pessimism index out of range")
+	}
+	return c, nil
+}
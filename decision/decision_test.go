@@ -0,0 +1,176 @@
+package decision
+
+import (
+	"math"
+	"testing"
+
+	evidence "github.com/sporkmonger/go-evidence"
+	"github.com/stretchr/testify/assert"
+)
+
+func weather() (*evidence.MassFunction, *Utilities) {
+	fr, _ := evidence.NewFrame("rain", "cloud", "sun")
+	mf := evidence.NewMassFunction(fr)
+	mf.Set(evidence.K("rain"), 0.3)
+	mf.Set(evidence.K("rain", "cloud"), 0.2)
+	mf.Set(evidence.K("cloud", "sun"), 0.1)
+	mf.Set(fr.Universe(), 0.4)
+	u, _ := NewUtilities(fr, []string{"umbrella", "hat", "stay"}, [][]float64{
+		{8.0, 6.0, 2.0},
+		{0.0, 5.0, 9.0},
+		{5.0, 5.0, 5.0},
+	})
+	return mf, u
+}
+
+func TestNewUtilities(t *testing.T) {
+	assert := assert.New(t)
+
+	_, u := weather()
+	assert.Equal([]string{"umbrella", "hat", "stay"}, u.Acts())
+	assert.Equal(3, u.Frame().Len())
+	assert.Equal(6.0, u.Utility("umbrella", "cloud"))
+	assert.Equal(9.0, u.Utility("hat", "sun"))
+	assert.True(math.IsNaN(u.Utility("swim", "sun")))
+	assert.True(math.IsNaN(u.Utility("hat", "snow")))
+
+	fr := u.Frame()
+	_, err := NewUtilities(fr, nil, nil)
+	assert.NotNil(err)
+	_, err = NewUtilities(fr, []string{"a", "b"}, [][]float64{{1, 2, 3}})
+	assert.NotNil(err)
+	_, err = NewUtilities(fr, []string{"a", "a"}, [][]float64{{1, 2, 3}, {1, 2, 3}})
+	assert.NotNil(err)
+	_, err = NewUtilities(fr, []string{"a"}, [][]float64{{1, 2}})
+	assert.NotNil(err)
+}
+
+func TestCriteria(t *testing.T) {
+	const tolerance = 0.00001
+	mf, u := weather()
+
+	tcs := []struct {
+		name      string
+		criterion func(*evidence.MassFunction, *Utilities) (*Choice, error)
+		act       string
+		scores    map[string]float64
+	}{
+		{
+			name:      "max-Bel",
+			criterion: MaxBelief,
+			act:       "stay",
+			scores:    map[string]float64{"umbrella": 4.6, "hat": 0.5, "stay": 5.0},
+		},
+		{
+			name:      "max-Pl",
+			criterion: MaxPlausibility,
+			act:       "umbrella",
+			scores:    map[string]float64{"umbrella": 7.8, "hat": 5.5, "stay": 5.0},
+		},
+		{
+			name:      "pignistic",
+			criterion: MaxPignistic,
+			act:       "umbrella",
+			scores: map[string]float64{
+				"umbrella": 8.0*1.6/3.0 + 6.0*0.85/3.0 + 2.0*0.55/3.0,
+				"hat":      5.0*0.85/3.0 + 9.0*0.55/3.0,
+				"stay":     5.0,
+			},
+		},
+		{
+			name: "cautious Hurwicz",
+			criterion: func(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+				return Hurwicz(mf, u, 0.1)
+			},
+			act:    "stay",
+			scores: map[string]float64{"umbrella": 4.92, "hat": 1.0, "stay": 5.0},
+		},
+		{
+			name: "Hurwicz",
+			criterion: func(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+				return Hurwicz(mf, u, 0.5)
+			},
+			act:    "umbrella",
+			scores: map[string]float64{"umbrella": 6.2, "hat": 3.0, "stay": 5.0},
+		},
+		{
+			// More pessimistic when there's more at stake
+			name: "Jaffray",
+			criterion: func(mf *evidence.MassFunction, u *Utilities) (*Choice, error) {
+				return Jaffray(mf, u, func(worst float64, best float64) float64 {
+					if best-worst > 5.0 {
+						return 0.8
+					}
+					return 0.2
+				})
+			},
+			act:    "umbrella",
+			scores: map[string]float64{"umbrella": 5.72, "hat": 2.34, "stay": 5.0},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			c, err := tc.criterion(mf, u)
+			assert.Nil(err)
+			assert.Equal(tc.act, c.Act)
+			for act, score := range tc.scores {
+				assert.InDelta(score, c.Scores[act], tolerance, act)
+			}
+			assert.InDelta(4.6, c.Intervals["umbrella"].Lower, tolerance)
+			assert.InDelta(7.8, c.Intervals["umbrella"].Upper, tolerance)
+			assert.InDelta(0.5, c.Intervals["hat"].Lower, tolerance)
+			assert.InDelta(5.5, c.Intervals["hat"].Upper, tolerance)
+			assert.InDelta(5.0, c.Intervals["stay"].Lower, tolerance)
+			assert.InDelta(5.0, c.Intervals["stay"].Upper, tolerance)
+		})
+	}
+}
+
+func TestCriteriaUnnamedAct(t *testing.T) {
+	assert := assert.New(t)
+
+	// An act may have an empty name, and still be chosen
+	fr, _ := evidence.NewFrame("a", "b")
+	mf := evidence.NewMassFunction(fr)
+	mf.Set(fr.Universe(), 1.0)
+	u, err := NewUtilities(fr, []string{"", "y"}, [][]float64{
+		{1.0, 1.0},
+		{0.0, 0.0},
+	})
+	assert.Nil(err)
+	c, err := MaxBelief(mf, u)
+	assert.Nil(err)
+	assert.Equal("", c.Act)
+}
+
+func TestCriteriaErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	mf, u := weather()
+	_, err := Hurwicz(mf, u, 1.5)
+	assert.NotNil(err)
+	_, err = Jaffray(mf, u, func(float64, float64) float64 { return -1.0 })
+	assert.NotNil(err)
+
+	other, _ := evidence.NewFrame("rain", "sun")
+	omf := evidence.NewMassFunction(other)
+	omf.Set(evidence.K("rain"), 1.0)
+	_, err = MaxBelief(omf, u)
+	assert.NotNil(err)
+
+	open := evidence.NewMassFunction(u.Frame())
+	open.SetOpenWorld(true)
+	open.Set(evidence.K(), 0.5)
+	open.Set(evidence.K("sun"), 0.5)
+	_, err = MaxPignistic(open, u)
+	assert.NotNil(err)
+
+	// Unbound MassFunctions only need to fit within the frame
+	unbound := &evidence.MassFunction{}
+	unbound.Set(evidence.K("sun"), 1.0)
+	c, err := MaxBelief(unbound, u)
+	assert.Nil(err)
+	assert.Equal("hat", c.Act)
+}
@@ -0,0 +1,132 @@
+package decision
+
+import (
+	evidence "github.com/sporkmonger/go-evidence"
+)
+
+// Rather than forcing a single choice, the criteria below return every act
+// that the evidence can't rule out, in the order the acts were given. When
+// more than one act survives, the evidence is too weak to choose between them
+// and the decision may need to be escalated.
+
+// survivors returns every act that isn't beaten by any other act.
+func (u *Utilities) survivors(beats func(winner int, loser int) bool) []string {
+	var acts []string
+	for i, act := range u.acts {
+		beaten := false
+		for j := range u.acts {
+			if j != i && beats(j, i) {
+				beaten = true
+				break
+			}
+		}
+		if !beaten {
+			acts = append(acts, act)
+		}
+	}
+	return acts
+}
+
+// IntervalDominance returns every act whose upper expected utility is at least
+// the lower expected utility of every other act. An act is only ruled out if
+// another act is certain to do better in expectation, however the evidence is
+// interpreted. Returns an error if the MassFunction's frame doesn't fit within
+// the utilities' frame or if it assigns mass to the empty set.
+func IntervalDominance(mf *evidence.MassFunction, u *Utilities) ([]string, error) {
+	if _, err := u.focalSets(mf); err != nil {
+		return nil, err
+	}
+	intervals, err := u.intervals(mf)
+	if err != nil {
+		return nil, err
+	}
+	return u.survivors(func(winner int, loser int) bool {
+		return intervals[u.acts[winner]].Lower > intervals[u.acts[loser]].Upper+epsilon
+	}), nil
+}
+
+// Maximality returns every act that no other act beats in expectation under
+// every probability distribution consistent with the evidence, i.e. the
+// acts a for which no other act b has a positive lower expectation of
+// u(b)-u(a). Since the comparison is made one distribution at a time, this
+// rules out at least as many acts as IntervalDominance. Returns an error if the
+// MassFunction's frame doesn't fit within the utilities' frame or if it
+// assigns mass to the empty set.
+func Maximality(mf *evidence.MassFunction, u *Utilities) ([]string, error) {
+	if _, err := u.focalSets(mf); err != nil {
+		return nil, err
+	}
+	// Differences cover every hypothesis of the frame, so once the focal sets
+	// have been checked there's nothing left to go wrong
+	return u.survivors(func(winner int, loser int) bool {
+		difference := u.values(winner)
+		for h, v := range u.values(loser) {
+			difference[h] -= v
+		}
+		lower, _ := mf.LowerExpectation(difference)
+		return lower > epsilon
+	}), nil
+}
+
+// EAdmissibility returns every act that maximizes expected utility under at
+// least one probability distribution in the credal set induced by the
+// evidence, the distributions that give each possibility at least as much
+// probability as its belief. Every E-admissible act is maximal, but acts that
+// are only ever second best may be maximal without being E-admissible. Each
+// act is checked by solving a linear program over the ways of allocating the
+// mass of each focal set among its hypotheses. Returns an error if the
+// MassFunction's frame doesn't fit within the utilities' frame or if it
+// assigns mass to the empty set.
+func EAdmissibility(mf *evidence.MassFunction, u *Utilities) ([]string, error) {
+	fs, err := u.focalSets(mf)
+	if err != nil {
+		return nil, err
+	}
+	// There's one variable for the share of each focal set's mass allocated to
+	// each of its hypotheses, and one surplus variable per competing act
+	shares := 0
+	for _, f := range fs {
+		shares += len(f.hypotheses)
+	}
+	competitors := len(u.acts) - 1
+	var acts []string
+	for i, act := range u.acts {
+		a := make([][]float64, 0, len(fs)+competitors)
+		b := make([]float64, 0, len(fs)+competitors)
+		// Each focal set's mass is allocated in full
+		col := 0
+		for _, f := range fs {
+			row := make([]float64, shares+competitors)
+			for range f.hypotheses {
+				row[col] = 1.0
+				col++
+			}
+			a = append(a, row)
+			b = append(b, f.mass)
+		}
+		// The act does at least as well as every other act:
+		// Σ p(θ)·(u(act,θ)-u(other,θ)) - surplus = 0
+		surplus := shares
+		for j := range u.acts {
+			if j == i {
+				continue
+			}
+			row := make([]float64, shares+competitors)
+			col = 0
+			for _, f := range fs {
+				for _, h := range f.hypotheses {
+					row[col] = u.utilities[i][h] - u.utilities[j][h]
+					col++
+				}
+			}
+			row[surplus] = -1.0
+			surplus++
+			a = append(a, row)
+			b = append(b, 0.0)
+		}
+		if feasible(a, b) {
+			acts = append(acts, act)
+		}
+	}
+	return acts, nil
+}
@@ -0,0 +1,92 @@
+package decision
+
+import (
+	"testing"
+
+	evidence "github.com/sporkmonger/go-evidence"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpreciseCriteria(t *testing.T) {
+	fr, _ := evidence.NewFrame("x", "y")
+	mf := evidence.NewMassFunction(fr)
+	mf.Set(evidence.K("x"), 0.1)
+	mf.Set(evidence.K("x", "y"), 0.9)
+	u, _ := NewUtilities(fr, []string{"a", "b", "c", "d", "e"}, [][]float64{
+		{1.0, 0.0},
+		{0.0, 1.0},
+		// A safe bet that's never the best bet
+		{0.45, 0.45},
+		// A safe bet that's always worse than c
+		{0.2, 0.2},
+		// Always worse than a
+		{0.9, -0.1},
+	})
+
+	tcs := []struct {
+		name      string
+		criterion func(*evidence.MassFunction, *Utilities) ([]string, error)
+		acts      []string
+	}{
+		{
+			name:      "interval dominance",
+			criterion: IntervalDominance,
+			acts:      []string{"a", "b", "c", "e"},
+		},
+		{
+			name:      "maximality",
+			criterion: Maximality,
+			acts:      []string{"a", "b", "c"},
+		},
+		{
+			name:      "E-admissibility",
+			criterion: EAdmissibility,
+			acts:      []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			acts, err := tc.criterion(mf, u)
+			assert.Nil(err)
+			assert.Equal(tc.acts, acts)
+		})
+	}
+}
+
+func TestImpreciseCriteriaAgree(t *testing.T) {
+	assert := assert.New(t)
+
+	// With precise enough evidence, every criterion settles on the same act
+	mf, u := weather()
+	precise := evidence.NewMassFunction(u.Frame())
+	precise.Set(evidence.K("rain"), 0.9)
+	precise.Set(evidence.K("rain", "cloud", "sun"), 0.1)
+	for _, criterion := range []func(*evidence.MassFunction, *Utilities) ([]string, error){
+		IntervalDominance, Maximality, EAdmissibility,
+	} {
+		acts, err := criterion(precise, u)
+		assert.Nil(err)
+		assert.Equal([]string{"umbrella"}, acts)
+	}
+
+	// Weather evidence is too vague to rule anything out
+	for _, criterion := range []func(*evidence.MassFunction, *Utilities) ([]string, error){
+		IntervalDominance, Maximality, EAdmissibility,
+	} {
+		acts, err := criterion(mf, u)
+		assert.Nil(err)
+		assert.Equal([]string{"umbrella", "hat", "stay"}, acts)
+	}
+
+	other, _ := evidence.NewFrame("rain", "snow")
+	omf := evidence.NewMassFunction(other)
+	omf.Set(evidence.K("snow"), 1.0)
+	for _, criterion := range []func(*evidence.MassFunction, *Utilities) ([]string, error){
+		IntervalDominance, Maximality, EAdmissibility,
+	} {
+		_, err := criterion(omf, u)
+		assert.NotNil(err)
+	}
+}
@@ -0,0 +1,99 @@
+package decision
+
+import (
+	"math"
+)
+
+// epsilon is the tolerance used by the simplex method to decide whether a
+// value is zero.
+const epsilon = 1e-9
+
+// feasible returns true if there is a vector x ≥ 0 such that a·x = b, where
+// every element of b is non-negative. It runs the first phase of the simplex
+// method, minimizing the sum of an artificial variable added to each row, and
+// uses Bland's rule to avoid cycling. The problem is feasible if the
+// artificial variables can all be driven to zero.
+func feasible(a [][]float64, b []float64) bool {
+	rows := len(a)
+	if rows == 0 {
+		return true
+	}
+	cols := len(a[0])
+	// The tableau holds the original variables, then the artificial variables,
+	// then the right-hand side
+	width := cols + rows + 1
+	tableau := make([][]float64, rows)
+	basis := make([]int, rows)
+	for i := range a {
+		tableau[i] = make([]float64, width)
+		copy(tableau[i], a[i])
+		tableau[i][cols+i] = 1.0
+		tableau[i][width-1] = b[i]
+		basis[i] = cols + i
+	}
+	// The objective row holds the reduced cost of each variable, which starts
+	// out as minus the sum of each column over the rows
+	objective := make([]float64, width)
+	for i := range tableau {
+		for j := 0; j < cols; j++ {
+			objective[j] -= tableau[i][j]
+		}
+		objective[width-1] -= tableau[i][width-1]
+	}
+	for {
+		// Bland's rule: enter the first variable that improves the objective
+		entering := -1
+		for j := 0; j < width-1; j++ {
+			if objective[j] < -epsilon {
+				entering = j
+				break
+			}
+		}
+		if entering < 0 {
+			break
+		}
+		// Leave by the minimum ratio test, breaking ties by the lowest index
+		leaving := -1
+		ratio := math.Inf(1)
+		for i := range tableau {
+			if tableau[i][entering] <= epsilon {
+				continue
+			}
+			r := tableau[i][width-1] / tableau[i][entering]
+			if r < ratio-epsilon || (r < ratio+epsilon && leaving >= 0 &&
+				basis[i] < basis[leaving]) {
+				leaving, ratio = i, r
+			}
+		}
+		if leaving < 0 {
+			// Phase one is bounded below by zero, so this can't happen
+			break
+		}
+		pivot(tableau, objective, leaving, entering)
+		basis[leaving] = entering
+	}
+	return -objective[width-1] <= epsilon*float64(rows)
+}
+
+// pivot makes the given column a basic variable for the given row.
+func pivot(tableau [][]float64, objective []float64, row int, col int) {
+	p := tableau[row][col]
+	for j := range tableau[row] {
+		tableau[row][j] /= p
+	}
+	eliminate := func(r []float64) {
+		f := r[col]
+		if f == 0.0 {
+			return
+		}
+		for j := range r {
+			r[j] -= f * tableau[row][j]
+		}
+	}
+	for i := range tableau {
+		if i != row {
+			eliminate(tableau[i])
+		}
+	}
+	eliminate(objective)
+}
@@ -0,0 +1,53 @@
+package decision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeasible(t *testing.T) {
+	tcs := []struct {
+		name     string
+		a        [][]float64
+		b        []float64
+		feasible bool
+	}{
+		{
+			name:     "empty",
+			feasible: true,
+		},
+		{
+			name:     "simplex",
+			a:        [][]float64{{1, 1, 1}},
+			b:        []float64{1},
+			feasible: true,
+		},
+		{
+			// x + y = 1 and x - y - s = 2 would need y < 0
+			name:     "infeasible",
+			a:        [][]float64{{1, 1, 0}, {1, -1, -1}},
+			b:        []float64{1, 2},
+			feasible: false,
+		},
+		{
+			// x + y = 1 and x - y - s = 0.5 holds for x = 0.75
+			name:     "feasible",
+			a:        [][]float64{{1, 1, 0}, {1, -1, -1}},
+			b:        []float64{1, 0.5},
+			feasible: true,
+		},
+		{
+			name:     "redundant",
+			a:        [][]float64{{1, 1}, {2, 2}, {1, 0}},
+			b:        []float64{1, 2, 0.25},
+			feasible: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.feasible, feasible(tc.a, tc.b))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errReliability = errors.New("reliability out of range")
+
+// Discount returns a new MassFunction scaled by the source's reliability, with
+// the remainder assigned to the whole frame. Returns an error if the
+// reliability is outside the range 0.0 <= r <= 1.0.
+func (mf *MassFunction) Discount(reliability float64) (dmf *MassFunction, err error) {
+	if reliability < 0.0 || reliability > 1.0 {
+		return nil, errReliability
+	}
+	frame, bound := mf.frameInfo()
+	return mf.discountOver(frame, bound, reliability), nil
+}
+
+// discountOver discounts the MassFunction over the given frame, which must
+// contain every hypothesis in its own frame, assigning the remainder to that
+// frame's universe.
+func (mf *MassFunction) discountOver(frame *Frame, bound bool,
+	reliability float64) *MassFunction {
+	dmf := &MassFunction{openWorld: mf.OpenWorld()}
+	dmf.bind(frame, bound)
+	universe := frame.universe()
+	dmf.setUnsafe(universe, 1.0-reliability)
+	for _, f := range mf.focalSets(frame) {
+		dmf.setUnsafe(f.key, dmf.value(f.key)+reliability*f.value)
+	}
+	return dmf
+}
+
+// Dediscount is the inverse of Discount. Returns an error if the reliability is
+// outside the range 0.0 < r <= 1.0, or if the MassFunction assigns less than
+// 1.0 - r to the whole frame.
+func (mf *MassFunction) Dediscount(reliability float64) (dmf *MassFunction, err error) {
+	if reliability <= 0.0 || reliability > 1.0 {
+		return nil, errReliability
+	}
+	mf.mux.Lock()
+	defer mf.mux.Unlock()
+	mf.init()
+	universe := mf.frame.universe()
+	if floatFixed(mf.value(universe)-(1.0-reliability), 5) < 0.0 {
+		return nil, errors.New("mass function is less uncertain than the reliability allows")
+	}
+	dmf = &MassFunction{openWorld: mf.openWorld}
+	dmf.bind(mf.frame, mf.bound)
+	for p, v := range mf.possibilities {
+		if p == universe {
+			v -= 1.0 - reliability
+		}
+		dmf.setUnsafe(p, v/reliability)
+	}
+	return dmf, nil
+}
+
+// CombineDiscounted combines MassFunctions according to the given rule after
+// discounting each by its source's reliability. Returns nil if no
+// MassFunctions are provided, if their frames are incompatible, if there isn't
+// one valid reliability per MassFunction, or if the rule itself returns nil.
+func CombineDiscounted(rule func(...*MassFunction) *MassFunction,
+	reliabilities []float64, mfns ...*MassFunction) *MassFunction {
+	if len(mfns) == 0 || len(reliabilities) != len(mfns) {
+		return nil
+	}
+	// Each source's ignorance is about the shared frame, not just the
+	// hypotheses that source happens to mention
+	frame, bound, err := alignFrames(mfns...)
+	if err != nil {
+		return nil
+	}
+	discounted := make([]*MassFunction, len(mfns))
+	for i, mf := range mfns {
+		if reliabilities[i] < 0.0 || reliabilities[i] > 1.0 {
+			return nil
+		}
+		discounted[i] = mf.discountOver(frame, bound, reliabilities[i])
+	}
+	return rule(discounted...)
+}
+
+// contextual combines a MassFunction with one simple mass function per
+// hypothesis, moving 1-factors[i] of each mass to the possibility given by op.
+func (mf *MassFunction) contextual(factors []float64,
+	op func(bitKey, int) bitKey) (cmf *MassFunction, err error) {
+	mf.mux.Lock()
+	defer mf.mux.Unlock()
+	mf.init()
+	if len(factors) != mf.frame.Len() {
+		return nil, fmt.Errorf("expected %d factors for %s, got %d",
+			mf.frame.Len(), mf.frame, len(factors))
+	}
+	for _, factor := range factors {
+		if factor < 0.0 || factor > 1.0 {
+			return nil, errReliability
+		}
+	}
+	masses := make(map[bitKey]float64, len(mf.possibilities))
+	for p, v := range mf.possibilities {
+		masses[p] = v
+	}
+	for i, factor := range factors {
+		if factor == 1.0 {
+			continue
+		}
+		next := make(map[bitKey]float64, len(masses))
+		for p, v := range masses {
+			next[p] += v * factor
+			next[op(p, i)] += v * (1.0 - factor)
+		}
+		masses = next
+	}
+	cmf = &MassFunction{}
+	cmf.bind(mf.frame, mf.bound)
+	for p, v := range masses {
+		cmf.setUnsafe(p, v)
+	}
+	cmf.openWorld = mf.openWorld || cmf.value(bitKey{}) != 0.0
+	return cmf, nil
+}
+
+// ContextualDiscount returns a new MassFunction weakened according to
+// contextual discounting, where reliabilities[i] is the source's reliability
+// when the i-th hypothesis of Frame().Hypotheses() is true. Returns an error
+// if there isn't one reliability per hypothesis or if any is out of range.
+func (mf *MassFunction) ContextualDiscount(reliabilities []float64) (*MassFunction, error) {
+	return mf.contextual(reliabilities, func(p bitKey, i int) bitKey {
+		return p.With(i)
+	})
+}
+
+// ContextualReinforce returns a new MassFunction strengthened according to
+// contextual reinforcement, with factors indexed like ContextualDiscount's
+// reliabilities and any resulting conflict left on the empty set. Returns an
+// error if there isn't one factor per hypothesis or if any is out of range.
+func (mf *MassFunction) ContextualReinforce(factors []float64) (*MassFunction, error) {
+	return mf.contextual(factors, func(p bitKey, i int) bitKey {
+		return p.Difference(singletonBitKey(i))
+	})
+}
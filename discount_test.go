@@ -0,0 +1,264 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscount(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	dmf, err := mf.Discount(0.8)
+	assert.Nil(err)
+	assert.True(dmf.Frame() == mf.Frame())
+	assert.InDelta(0.28, dmf.Get(K("red")), tolerance)
+	assert.InDelta(0.2, dmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.048, dmf.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.28, dmf.Get(K("red", "yellow", "green")), tolerance)
+	assert.True(dmf.Valid())
+
+	// De-discounting recovers the original MassFunction
+	rmf, err := dmf.Dediscount(0.8)
+	assert.Nil(err)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(mf.Get(p), rmf.Get(p), tolerance, p.String())
+	}
+	assert.True(rmf.Valid())
+
+	// A totally unreliable source tells us nothing
+	dmf, err = mf.Discount(0.0)
+	assert.Nil(err)
+	assert.InDelta(1.0, dmf.Get(K("red", "yellow", "green")), tolerance)
+	assert.InDelta(0.0, dmf.Get(K("red")), tolerance)
+
+	// A fully reliable source is left alone
+	dmf, err = mf.Discount(1.0)
+	assert.Nil(err)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(mf.Get(p), dmf.Get(p), tolerance, p.String())
+	}
+}
+
+func TestDiscountErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	mf := trafficLight()
+	_, err := mf.Discount(-0.1)
+	assert.NotNil(err)
+	_, err = mf.Discount(1.1)
+	assert.NotNil(err)
+	_, err = mf.Dediscount(0.0)
+	assert.NotNil(err)
+	// Only 0.1 is assigned to the frame, so no source with reliability 0.5
+	// could have produced this
+	_, err = mf.Dediscount(0.5)
+	assert.NotNil(err)
+}
+
+func TestCombineDiscounted(t *testing.T) {
+	const tolerance = 0.00001
+
+	fr, _ := NewFrame("a", "b")
+	mf1 := NewMassFunction(fr)
+	mf1.Set(K("a"), 1.0)
+	mf2 := NewMassFunction(fr)
+	mf2.Set(K("b"), 1.0)
+
+	tcs := []struct {
+		name          string
+		rule          func(...*MassFunction) *MassFunction
+		reliabilities []float64
+		expected      map[functionKey]float64
+	}{
+		{
+			// Completely conflicting sources can be combined once discounted
+			name:          "Dempster",
+			rule:          CombineConjunctive,
+			reliabilities: []float64{0.9, 0.5},
+			expected: map[functionKey]float64{
+				K("a"):      0.45 / 0.55,
+				K("b"):      0.05 / 0.55,
+				K("a", "b"): 0.05 / 0.55,
+			},
+		},
+		{
+			name:          "unnormalized",
+			rule:          CombineConjunctiveUnnormalized,
+			reliabilities: []float64{0.9, 0.5},
+			expected: map[functionKey]float64{
+				K():         0.45,
+				K("a"):      0.45,
+				K("b"):      0.05,
+				K("a", "b"): 0.05,
+			},
+		},
+		{
+			name:          "Yager",
+			rule:          CombineYager,
+			reliabilities: []float64{0.9, 0.5},
+			expected: map[functionKey]float64{
+				K("a"):      0.45,
+				K("b"):      0.05,
+				K("a", "b"): 0.5,
+			},
+		},
+		{
+			name:          "fully reliable",
+			rule:          CombineDisjunctive,
+			reliabilities: []float64{1.0, 1.0},
+			expected: map[functionKey]float64{
+				K("a", "b"): 1.0,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			cf := CombineDiscounted(tc.rule, tc.reliabilities, mf1, mf2)
+			assert.NotNil(cf)
+			for _, p := range fr.Powerset() {
+				assert.InDelta(tc.expected[p], cf.Get(p), tolerance, p.String())
+			}
+		})
+	}
+}
+
+func TestCombineDiscountedUnboundFrames(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// Each source is discounted onto the combined frame, so a completely
+	// unreliable source rules nothing out, even hypotheses it never mentions
+	mf1 := &MassFunction{}
+	mf1.Set(K("a"), 1.0)
+	mf1.Set(K("b"), 0.0)
+	mf2 := &MassFunction{}
+	mf2.Set(K("a", "b", "c"), 1.0)
+	cf := CombineDiscounted(CombineConjunctive, []float64{0.0, 1.0}, mf1, mf2)
+	assert.NotNil(cf)
+	assert.InDelta(1.0, cf.Get(K("a", "b", "c")), tolerance)
+	assert.InDelta(0.0, cf.Get(K("a", "b")), tolerance)
+
+	cf = CombineDiscounted(CombineConjunctive, []float64{0.6, 1.0}, mf1, mf2)
+	assert.NotNil(cf)
+	assert.InDelta(0.6, cf.Get(K("a")), tolerance)
+	assert.InDelta(0.4, cf.Get(K("a", "b", "c")), tolerance)
+	assert.InDelta(0.0, cf.Get(K("a", "b")), tolerance)
+}
+
+func TestCombineDiscountedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	mf := trafficLight()
+	assert.Nil(CombineDiscounted(CombineConjunctive, nil))
+	assert.Nil(CombineDiscounted(CombineConjunctive, []float64{0.5}, mf, mf))
+	assert.Nil(CombineDiscounted(CombineConjunctive, []float64{0.5, 1.5}, mf, mf))
+	fr, _ := NewFrame("a", "b")
+	other := NewMassFunction(fr)
+	other.Set(K("a"), 1.0)
+	assert.Nil(CombineDiscounted(CombineConjunctive, []float64{0.5, 0.5}, mf, other))
+}
+
+func TestContextualDiscount(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// The source is always right about red, but often wrong about yellow
+	mf := trafficLight()
+	assert.Equal([]string{"red", "yellow", "green"}, mf.Frame().Hypotheses())
+	reliabilities := []float64{1.0, 0.5, 0.8}
+	dmf, err := mf.ContextualDiscount(reliabilities)
+	assert.Nil(err)
+	assert.True(dmf.Valid())
+	assert.False(dmf.OpenWorld())
+	assert.InDelta(0.14, dmf.Get(K("red")), tolerance)
+	assert.InDelta(0.2, dmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.075, dmf.Get(K("green")), tolerance)
+	assert.InDelta(0.188, dmf.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.06, dmf.Get(K("red", "green")), tolerance)
+	assert.InDelta(0.165, dmf.Get(K("yellow", "green")), tolerance)
+	assert.InDelta(0.172, dmf.Get(K("red", "yellow", "green")), tolerance)
+
+	// Contextual discounting is a disjunctive combination with simple mass
+	// functions on each hypothesis
+	yellow := NewMassFunction(mf.Frame())
+	yellow.SetOpenWorld(true)
+	yellow.Set(K(), 0.5)
+	yellow.Set(K("yellow"), 0.5)
+	green := NewMassFunction(mf.Frame())
+	green.SetOpenWorld(true)
+	green.Set(K(), 0.8)
+	green.Set(K("green"), 0.2)
+	cf := CombineDisjunctive(mf, yellow, green)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(cf.Get(p), dmf.Get(p), tolerance, p.String())
+	}
+
+	// The plausibility of each hypothesis is discounted by its reliability
+	pf, dpf := mf.Plausibility(), dmf.Plausibility()
+	for i, h := range mf.Frame().Hypotheses() {
+		expected := 1.0 - reliabilities[i] + reliabilities[i]*pf.Get(K(h))
+		assert.InDelta(expected, dpf.Get(K(h)), tolerance, h)
+	}
+}
+
+func TestContextualReinforce(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	rmf, err := mf.ContextualReinforce([]float64{1.0, 0.5, 0.8})
+	assert.Nil(err)
+	assert.True(rmf.Valid())
+	assert.True(rmf.OpenWorld())
+	assert.InDelta(0.159, rmf.Get(K()), tolerance)
+	assert.InDelta(0.4, rmf.Get(K("red")), tolerance)
+	assert.InDelta(0.129, rmf.Get(K("yellow")), tolerance)
+	assert.InDelta(0.136, rmf.Get(K("green")), tolerance)
+	assert.InDelta(0.04, rmf.Get(K("red", "yellow")), tolerance)
+	assert.InDelta(0.08, rmf.Get(K("red", "green")), tolerance)
+	assert.InDelta(0.016, rmf.Get(K("yellow", "green")), tolerance)
+	assert.InDelta(0.04, rmf.Get(K("red", "yellow", "green")), tolerance)
+
+	// Contextual reinforcement is a conjunctive combination with simple mass
+	// functions on the complement of each hypothesis
+	yellow := NewMassFunction(mf.Frame())
+	yellow.Set(K("red", "green"), 0.5)
+	yellow.Set(K("red", "yellow", "green"), 0.5)
+	green := NewMassFunction(mf.Frame())
+	green.Set(K("red", "yellow"), 0.2)
+	green.Set(K("red", "yellow", "green"), 0.8)
+	cf := CombineConjunctiveUnnormalized(mf, yellow, green)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(cf.Get(p), rmf.Get(p), tolerance, p.String())
+	}
+}
+
+func TestContextualErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	mf := trafficLight()
+	_, err := mf.ContextualDiscount([]float64{1.0, 0.5})
+	assert.NotNil(err)
+	_, err = mf.ContextualDiscount([]float64{1.0, 0.5, 1.5})
+	assert.NotNil(err)
+	_, err = mf.ContextualReinforce([]float64{1.0, 0.5, 0.8, 0.8})
+	assert.NotNil(err)
+	_, err = mf.ContextualReinforce([]float64{-1.0, 0.5, 0.8})
+	assert.NotNil(err)
+
+	// Unit factors leave the MassFunction alone
+	dmf, err := mf.ContextualDiscount([]float64{1.0, 1.0, 1.0})
+	assert.Nil(err)
+	rmf, err := mf.ContextualReinforce([]float64{1.0, 1.0, 1.0})
+	assert.Nil(err)
+	for _, p := range mf.Powerset() {
+		assert.InDelta(mf.Get(p), dmf.Get(p), 0.00001, p.String())
+		assert.InDelta(mf.Get(p), rmf.Get(p), 0.00001, p.String())
+	}
+	assert.False(rmf.OpenWorld())
+}
@@ -1 +1,241 @@
 package evidence
+
+import (
+	"math"
+)
+
+// Distances between MassFunctions measure how far apart two bodies of evidence
+// are. Each distance is computed over the frame that the two MassFunctions
+// would be combined over, and returns an error if their frames are
+// incompatible. The distances between beliefs and plausibilities also return an
+// error if the frame is too large to enumerate.
+
+// massDifferences returns the difference between the masses of two
+// MassFunctions over their common frame, for every possibility that either
+// MassFunction assigns mass to, ordered by bitKey.
+func massDifferences(mf1 *MassFunction, mf2 *MassFunction) ([]focal, error) {
+	frame, _, err := alignFrames(mf1, mf2)
+	if err != nil {
+		return nil, err
+	}
+	masses := make(map[bitKey]float64)
+	for _, f := range mf1.focalSets(frame) {
+		masses[f.key] += f.value
+	}
+	for _, f := range mf2.focalSets(frame) {
+		masses[f.key] -= f.value
+	}
+	return sortedFocals(masses), nil
+}
+
+// jaccard returns the Jaccard similarity |A∩B|/|A∪B| of two possibilities. The
+// empty set is taken to be entirely similar to itself.
+func jaccard(bk1 bitKey, bk2 bitKey) float64 {
+	union := bk1.Union(bk2).Len()
+	if union == 0 {
+		return 1.0
+	}
+	return float64(bk1.Intersect(bk2).Len()) / float64(union)
+}
+
+// jaccardMatrix returns the Jaccard similarity of every pair of possibilities.
+func jaccardMatrix(bks []bitKey) [][]float64 {
+	matrix := make([][]float64, len(bks))
+	for i := range bks {
+		matrix[i] = make([]float64, len(bks))
+		for j := range bks {
+			matrix[i][j] = jaccard(bks[i], bks[j])
+		}
+	}
+	return matrix
+}
+
+// JousselmeDistance returns the distance of Jousselme, Grenier and Bossé
+// between two MassFunctions, sqrt(½(m1-m2)ᵀD(m1-m2)), where D is the Jaccard
+// similarity matrix of their focal sets. Unlike a plain Euclidean distance,
+// mass assigned to overlapping focal sets is considered closer than mass
+// assigned to disjoint ones. The distance is 0.0 for identical MassFunctions
+// and 1.0 for categorical MassFunctions on disjoint focal sets.
+func JousselmeDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	diffs, err := massDifferences(mf1, mf2)
+	if err != nil {
+		return 0.0, err
+	}
+	bks := make([]bitKey, len(diffs))
+	for i, d := range diffs {
+		bks[i] = d.key
+	}
+	d := jaccardMatrix(bks)
+	sum := 0.0
+	for i := range diffs {
+		for j := range diffs {
+			sum += diffs[i].value * d[i][j] * diffs[j].value
+		}
+	}
+	// Rounding errors can leave a tiny negative sum for identical inputs
+	return math.Sqrt(math.Max(sum/2.0, 0.0)), nil
+}
+
+// EuclideanDistance returns the Euclidean distance between two MassFunctions,
+// treating each as a vector of masses indexed by possibility.
+func EuclideanDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	diffs, err := massDifferences(mf1, mf2)
+	if err != nil {
+		return 0.0, err
+	}
+	sum := 0.0
+	for _, d := range diffs {
+		sum += d.value * d.value
+	}
+	return math.Sqrt(sum), nil
+}
+
+// BhattacharyyaDistance returns the Bhattacharyya distance between two
+// MassFunctions, sqrt(1-Σ_A sqrt(m1(A)m2(A))). The distance is 0.0 for
+// identical MassFunctions and 1.0 for MassFunctions that share no focal sets.
+func BhattacharyyaDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	frame, _, err := alignFrames(mf1, mf2)
+	if err != nil {
+		return 0.0, err
+	}
+	masses := make(map[bitKey]float64)
+	for _, f := range mf1.focalSets(frame) {
+		masses[f.key] = f.value
+	}
+	coefficient := 0.0
+	for _, f := range mf2.focalSets(frame) {
+		coefficient += math.Sqrt(masses[f.key] * f.value)
+	}
+	return math.Sqrt(math.Max(1.0-coefficient, 0.0)), nil
+}
+
+// TessemDistance returns Tessem's pignistic distance between two
+// MassFunctions, the largest difference between the pignistic probabilities
+// that they assign to any possibility.
+func TessemDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	frame, _, err := alignFrames(mf1, mf2)
+	if err != nil {
+		return 0.0, err
+	}
+	betP := make(map[int]float64)
+	for _, f := range mf1.focalSets(frame) {
+		elements := f.key.Elements()
+		for _, i := range elements {
+			betP[i] += f.value / float64(len(elements))
+		}
+	}
+	for _, f := range mf2.focalSets(frame) {
+		elements := f.key.Elements()
+		for _, i := range elements {
+			betP[i] -= f.value / float64(len(elements))
+		}
+	}
+	// The possibility with the largest difference in either direction is made
+	// up of every hypothesis whose difference has that sign
+	over, under := 0.0, 0.0
+	for _, d := range betP {
+		if d > 0.0 {
+			over += d
+		} else {
+			under -= d
+		}
+	}
+	return math.Max(over, under), nil
+}
+
+// denseDifferences returns the difference between two MassFunctions, as
+// transformed into dense vectors over their common frame by the given
+// transform. Returns an error if the frames are incompatible or too large to
+// enumerate.
+func denseDifferences(mf1 *MassFunction, mf2 *MassFunction,
+	transform func([]float64) []float64) ([]float64, error) {
+	frame, _, err := alignFrames(mf1, mf2)
+	if err != nil {
+		return nil, err
+	}
+	v1, err := mf1.denseOver(frame)
+	if err != nil {
+		return nil, err
+	}
+	v2, err := mf2.denseOver(frame)
+	if err != nil {
+		return nil, err
+	}
+	v1, v2 = transform(v1), transform(v2)
+	for i := range v1 {
+		v1[i] -= v2[i]
+	}
+	return v1, nil
+}
+
+// beliefs transforms a dense vector of masses into beliefs.
+func beliefs(v []float64) []float64 {
+	zetaSubsets(v)
+	return v
+}
+
+// plausibilities transforms a dense vector of masses into plausibilities.
+func plausibilities(v []float64) []float64 {
+	zetaSubsets(v)
+	return dualize(v)
+}
+
+// l1Norm returns the sum of the absolute values of a vector.
+func l1Norm(v []float64) (norm float64) {
+	for _, x := range v {
+		norm += math.Abs(x)
+	}
+	return norm
+}
+
+// lInfNorm returns the largest absolute value in a vector.
+func lInfNorm(v []float64) (norm float64) {
+	for _, x := range v {
+		norm = math.Max(norm, math.Abs(x))
+	}
+	return norm
+}
+
+// BeliefL1Distance returns the L1 distance between the belief functions of two
+// MassFunctions, the sum over every possibility of the absolute difference
+// between their beliefs.
+func BeliefL1Distance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	v, err := denseDifferences(mf1, mf2, beliefs)
+	if err != nil {
+		return 0.0, err
+	}
+	return l1Norm(v), nil
+}
+
+// BeliefLInfDistance returns the L∞ distance between the belief functions of
+// two MassFunctions, the largest absolute difference between their beliefs in
+// any possibility.
+func BeliefLInfDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	v, err := denseDifferences(mf1, mf2, beliefs)
+	if err != nil {
+		return 0.0, err
+	}
+	return lInfNorm(v), nil
+}
+
+// PlausibilityL1Distance returns the L1 distance between the plausibility
+// functions of two MassFunctions, the sum over every possibility of the
+// absolute difference between their plausibilities.
+func PlausibilityL1Distance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	v, err := denseDifferences(mf1, mf2, plausibilities)
+	if err != nil {
+		return 0.0, err
+	}
+	return l1Norm(v), nil
+}
+
+// PlausibilityLInfDistance returns the L∞ distance between the plausibility
+// functions of two MassFunctions, the largest absolute difference between
+// their plausibilities of any possibility.
+func PlausibilityLInfDistance(mf1 *MassFunction, mf2 *MassFunction) (float64, error) {
+	v, err := denseDifferences(mf1, mf2, plausibilities)
+	if err != nil {
+		return 0.0, err
+	}
+	return lInfNorm(v), nil
+}
@@ -0,0 +1,157 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJaccardMatrix(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	d := jaccardMatrix([]bitKey{{}, bk(0), bk(0, 1), bk(1, 2)})
+	assert.InDeltaSlice([]float64{1.0, 0.0, 0.0, 0.0}, d[0], tolerance)
+	assert.InDeltaSlice([]float64{0.0, 1.0, 0.5, 0.0}, d[1], tolerance)
+	assert.InDeltaSlice([]float64{0.0, 0.5, 1.0, 1.0 / 3.0}, d[2], tolerance)
+	assert.InDeltaSlice([]float64{0.0, 0.0, 1.0 / 3.0, 1.0}, d[3], tolerance)
+}
+
+func TestDistances(t *testing.T) {
+	const tolerance = 0.00001
+
+	fr, _ := NewFrame("a", "b", "c")
+	mf1 := NewMassFunction(fr)
+	mf1.Set(K("a"), 0.5)
+	mf1.Set(K("a", "b"), 0.3)
+	mf1.Set(K("a", "b", "c"), 0.2)
+	mf2 := NewMassFunction(fr)
+	mf2.Set(K("b"), 0.4)
+	mf2.Set(K("b", "c"), 0.4)
+	mf2.Set(K("a", "b", "c"), 0.2)
+	a := NewMassFunction(fr)
+	a.Set(K("a"), 1.0)
+	b := NewMassFunction(fr)
+	b.Set(K("b"), 1.0)
+
+	tcs := []struct {
+		name     string
+		distance func(*MassFunction, *MassFunction) (float64, error)
+		expected float64
+		disjoint float64
+	}{
+		{
+			name:     "Jousselme",
+			distance: JousselmeDistance,
+			expected: 0.62048,
+			disjoint: 1.0,
+		},
+		{
+			name:     "Euclidean",
+			distance: EuclideanDistance,
+			expected: 0.81240,
+			disjoint: 1.41421,
+		},
+		{
+			name:     "Bhattacharyya",
+			distance: BhattacharyyaDistance,
+			expected: 0.89443,
+			disjoint: 1.0,
+		},
+		{
+			name:     "Tessem",
+			distance: TessemDistance,
+			expected: 0.65,
+			disjoint: 1.0,
+		},
+		{
+			name:     "belief L1",
+			distance: BeliefL1Distance,
+			expected: 2.6,
+			disjoint: 4.0,
+		},
+		{
+			name:     "belief L∞",
+			distance: BeliefLInfDistance,
+			expected: 0.8,
+			disjoint: 1.0,
+		},
+		{
+			name:     "plausibility L1",
+			distance: PlausibilityL1Distance,
+			expected: 2.6,
+			disjoint: 4.0,
+		},
+		{
+			name:     "plausibility L∞",
+			distance: PlausibilityLInfDistance,
+			expected: 0.8,
+			disjoint: 1.0,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			d, err := tc.distance(mf1, mf2)
+			assert.Nil(err)
+			assert.InDelta(tc.expected, d, tolerance)
+			d, err = tc.distance(mf2, mf1)
+			assert.Nil(err)
+			assert.InDelta(tc.expected, d, tolerance)
+			d, err = tc.distance(mf1, mf1)
+			assert.Nil(err)
+			assert.InDelta(0.0, d, tolerance)
+			d, err = tc.distance(a, b)
+			assert.Nil(err)
+			assert.InDelta(tc.disjoint, d, tolerance)
+
+			other, _ := NewFrame("x", "y")
+			x := NewMassFunction(other)
+			x.Set(K("x"), 1.0)
+			_, err = tc.distance(mf1, x)
+			assert.NotNil(err)
+		})
+	}
+}
+
+func TestDistancesUnboundFrames(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// Unbound MassFunctions are compared over their merged frame
+	mf1 := &MassFunction{}
+	mf1.Set(K("a"), 0.6)
+	mf1.Set(K("a", "b"), 0.4)
+	mf2 := &MassFunction{}
+	mf2.Set(K("a", "b"), 0.4)
+	mf2.Set(K("c"), 0.6)
+	d, err := JousselmeDistance(mf1, mf2)
+	assert.Nil(err)
+	assert.InDelta(0.6, d, tolerance)
+	d, err = TessemDistance(mf1, mf2)
+	assert.Nil(err)
+	assert.InDelta(0.6, d, tolerance)
+}
+
+func TestDistancesLargeFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	frame, hypotheses := largeFrame()
+	mf1 := NewMassFunction(frame)
+	mf1.Set(K("h0"), 1.0)
+	mf2 := NewMassFunction(frame)
+	mf2.Set(K(hypotheses...), 1.0)
+
+	// Distances between masses don't need to enumerate the frame
+	d, err := JousselmeDistance(mf1, mf2)
+	assert.Nil(err)
+	assert.True(d > 0.0)
+	for _, distance := range []func(*MassFunction, *MassFunction) (float64, error){
+		BeliefL1Distance, BeliefLInfDistance,
+		PlausibilityL1Distance, PlausibilityLInfDistance,
+	} {
+		_, err := distance(mf1, mf2)
+		assert.Equal(errFrameTooLarge, err)
+	}
+}
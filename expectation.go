@@ -0,0 +1,112 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// expectation weights a summary of the values of a function within each focal
+// set of a MassFunction by the focal set's mass. Returns an error if the
+// MassFunction assigns mass to the empty set, or if the function has no value
+// for a hypothesis of a focal set.
+func (mf *MassFunction) expectation(values map[string]float64,
+	summarize func(float64, float64) float64) (float64, error) {
+	frame, _ := mf.frameInfo()
+	hypotheses := frame.Hypotheses()
+	expectation := 0.0
+	for _, f := range mf.focalSets(frame) {
+		if f.key.IsEmpty() {
+			return 0.0, errors.New("mass assigned to the empty set has no expectation")
+		}
+		summary := math.NaN()
+		for _, i := range f.key.Elements() {
+			v, ok := values[hypotheses[i]]
+			if !ok {
+				return 0.0, fmt.Errorf("no value for hypothesis %q", hypotheses[i])
+			}
+			if math.IsNaN(summary) {
+				summary = v
+			} else {
+				summary = summarize(summary, v)
+			}
+		}
+		expectation += f.value * summary
+	}
+	return expectation, nil
+}
+
+// LowerExpectation returns the lower expectation of a real-valued function of
+// the hypotheses with respect to the MassFunction, the least expected value
+// under any probability distribution consistent with its beliefs. This is the
+// Choquet integral of the function with respect to the MassFunction's
+// BeliefFunction, computed as the sum of the smallest value within each focal
+// set weighted by its mass. Returns an error if the MassFunction assigns mass
+// to the empty set, or if the function has no value for a hypothesis that mass
+// is assigned to.
+func (mf *MassFunction) LowerExpectation(values map[string]float64) (float64, error) {
+	return mf.expectation(values, math.Min)
+}
+
+// UpperExpectation returns the upper expectation of a real-valued function of
+// the hypotheses with respect to the MassFunction, the greatest expected value
+// under any probability distribution consistent with its beliefs. This is the
+// Choquet integral of the function with respect to the MassFunction's
+// PlausibilityFunction, computed as the sum of the largest value within each
+// focal set weighted by its mass. Returns an error if the MassFunction assigns
+// mass to the empty set, or if the function has no value for a hypothesis that
+// mass is assigned to.
+func (mf *MassFunction) UpperExpectation(values map[string]float64) (float64, error) {
+	return mf.expectation(values, math.Max)
+}
+
+// choquet returns the Choquet integral of a real-valued function of the
+// hypotheses of a function's frame with respect to the function's values, with
+// the hypotheses sorted by increasing value:
+// f(x1)·g(Ω) + Σ_{i≥2} (f(xi)-f(x(i-1)))·g({xi,...,xn}).
+func (f *Function) choquet(values map[string]float64) (float64, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.init()
+	hypotheses := f.frame.Hypotheses()
+	for _, h := range hypotheses {
+		if _, ok := values[h]; !ok {
+			return 0.0, fmt.Errorf("no value for hypothesis %q", h)
+		}
+	}
+	if len(hypotheses) == 0 {
+		return 0.0, nil
+	}
+	order := make([]int, len(hypotheses))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[hypotheses[order[i]]] < values[hypotheses[order[j]]]
+	})
+	upper := f.frame.universe()
+	integral := values[hypotheses[order[0]]] * f.value(upper)
+	for k := 1; k < len(order); k++ {
+		upper = upper.Difference(singletonBitKey(order[k-1]))
+		step := values[hypotheses[order[k]]] - values[hypotheses[order[k-1]]]
+		integral += step * f.value(upper)
+	}
+	return integral, nil
+}
+
+// Choquet returns the Choquet integral of a real-valued function of the
+// hypotheses with respect to the BeliefFunction, which is the lower
+// expectation of the function. Returns an error if the function has no value
+// for some hypothesis of the frame.
+func (bf *BeliefFunction) Choquet(values map[string]float64) (float64, error) {
+	return bf.choquet(values)
+}
+
+// Choquet returns the Choquet integral of a real-valued function of the
+// hypotheses with respect to the PlausibilityFunction, which is the upper
+// expectation of the function. Returns an error if the function has no value
+// for some hypothesis of the frame.
+func (pf *PlausibilityFunction) Choquet(values map[string]float64) (float64, error) {
+	return pf.choquet(values)
+}
@@ -0,0 +1,80 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectations(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// The cost of each light, where running a red light is expensive
+	mf := trafficLight()
+	cost := map[string]float64{"red": 100.0, "yellow": 10.0, "green": -5.0}
+	lower, err := mf.LowerExpectation(cost)
+	assert.Nil(err)
+	// Lowest cost within each focal set, weighted by mass
+	assert.InDelta(0.35*100+0.25*10+0.15*-5+0.06*10+0.05*-5+0.04*-5+0.1*-5,
+		lower, tolerance)
+	upper, err := mf.UpperExpectation(cost)
+	assert.Nil(err)
+	assert.InDelta(0.35*100+0.25*10+0.15*-5+0.06*100+0.05*100+0.04*10+0.1*100,
+		upper, tolerance)
+
+	// The Choquet integrals with respect to belief and plausibility agree
+	choquet, err := mf.Belief().Choquet(cost)
+	assert.Nil(err)
+	assert.InDelta(lower, choquet, tolerance)
+	choquet, err = mf.Plausibility().Choquet(cost)
+	assert.Nil(err)
+	assert.InDelta(upper, choquet, tolerance)
+
+	// The pignistic expectation lies in between
+	betP := mf.Pignistic()
+	pignistic := 0.0
+	for h, v := range cost {
+		pignistic += betP.Get(K(h)) * v
+	}
+	assert.True(lower <= pignistic && pignistic <= upper)
+
+	// For a probability distribution, both are the ordinary expectation
+	bayesian := &MassFunction{}
+	bayesian.Set(K("red"), 0.5)
+	bayesian.Set(K("green"), 0.5)
+	lower, _ = bayesian.LowerExpectation(cost)
+	upper, _ = bayesian.UpperExpectation(cost)
+	assert.InDelta(47.5, lower, tolerance)
+	assert.InDelta(47.5, upper, tolerance)
+}
+
+func TestExpectationErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	mf := trafficLight()
+	partial := map[string]float64{"red": 1.0, "yellow": 2.0}
+	_, err := mf.LowerExpectation(partial)
+	assert.NotNil(err)
+	_, err = mf.UpperExpectation(partial)
+	assert.NotNil(err)
+	_, err = mf.Belief().Choquet(partial)
+	assert.NotNil(err)
+	_, err = mf.Plausibility().Choquet(partial)
+	assert.NotNil(err)
+
+	open := &MassFunction{}
+	open.SetOpenWorld(true)
+	open.Set(K(), 0.5)
+	open.Set(K("red"), 0.5)
+	_, err = open.LowerExpectation(partial)
+	assert.NotNil(err)
+
+	// Hypotheses without mass don't need a value
+	mf = &MassFunction{}
+	mf.Set(K("red", "yellow"), 1.0)
+	mf.Set(K("green"), 0.0)
+	lower, err := mf.LowerExpectation(partial)
+	assert.Nil(err)
+	assert.InDelta(1.0, lower, 0.00001)
+}
@@ -0,0 +1,337 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Frame is a frame of discernment, the ordered set of mutually exclusive
+// hypotheses over which a function assigns its values. Frames are immutable
+// once created and may be shared between functions.
+type Frame struct {
+	hypotheses []string
+	index      map[string]int
+	// variables is only set for product frames
+	variables []*Variable
+}
+
+// NewFrame creates a Frame from an ordered list of hypotheses. Hypotheses must
+// be valid focus key names and may not be repeated.
+func NewFrame(hypotheses ...string) (*Frame, error) {
+	fr := &Frame{
+		hypotheses: make([]string, 0, len(hypotheses)),
+		index:      make(map[string]int, len(hypotheses)),
+	}
+	for _, h := range hypotheses {
+		if !keyValidator.MatchString(h) {
+			return nil, fmt.Errorf(
+				"invalid hypothesis name (%q), must be lowercase alphanumeric or hyphen", h)
+		}
+		if _, ok := fr.index[h]; ok {
+			return nil, fmt.Errorf("duplicate hypothesis name (%q)", h)
+		}
+		fr.index[h] = len(fr.hypotheses)
+		fr.hypotheses = append(fr.hypotheses, h)
+	}
+	return fr, nil
+}
+
+// extend returns a new Frame with any previously unseen hypotheses appended.
+// The receiver is returned unchanged if there's nothing to add. A nil
+// receiver is treated as an empty frame.
+func (fr *Frame) extend(hypotheses ...string) *Frame {
+	var added []string
+	for _, h := range hypotheses {
+		if !fr.Contains(h) {
+			added = append(added, h)
+		}
+	}
+	if len(added) == 0 && fr != nil {
+		return fr
+	}
+	nfr := &Frame{
+		hypotheses: make([]string, 0, fr.Len()+len(added)),
+		index:      make(map[string]int, fr.Len()+len(added)),
+	}
+	for _, h := range append(fr.Hypotheses(), added...) {
+		if _, ok := nfr.index[h]; ok {
+			continue
+		}
+		nfr.index[h] = len(nfr.hypotheses)
+		nfr.hypotheses = append(nfr.hypotheses, h)
+	}
+	return nfr
+}
+
+// Len returns the number of hypotheses in the frame.
+func (fr *Frame) Len() int {
+	if fr == nil {
+		return 0
+	}
+	return len(fr.hypotheses)
+}
+
+// Hypotheses returns a copy of the frame's hypotheses in order.
+func (fr *Frame) Hypotheses() []string {
+	if fr == nil {
+		return []string{}
+	}
+	hs := make([]string, len(fr.hypotheses))
+	copy(hs, fr.hypotheses)
+	return hs
+}
+
+// Index returns the position of a hypothesis within the frame, or -1 if the
+// hypothesis is not part of the frame.
+func (fr *Frame) Index(hypothesis string) int {
+	if fr == nil {
+		return -1
+	}
+	if i, ok := fr.index[hypothesis]; ok {
+		return i
+	}
+	return -1
+}
+
+// Contains returns true if the hypothesis is part of the frame.
+func (fr *Frame) Contains(hypothesis string) bool {
+	return fr.Index(hypothesis) >= 0
+}
+
+// key converts a functionKey into a bitKey over the frame. Returns false if the
+// functionKey contains hypotheses that aren't part of the frame.
+func (fr *Frame) key(fk functionKey) (bk bitKey, ok bool) {
+	for _, focus := range fk.FocalElements() {
+		i := fr.Index(string(focus))
+		if i < 0 {
+			return bitKey{}, false
+		}
+		bk = bk.With(i)
+	}
+	return bk, true
+}
+
+// functionKey converts a bitKey over the frame back into a functionKey.
+func (fr *Frame) functionKey(bk bitKey) functionKey {
+	is := bk.Elements()
+	focals := make([]string, len(is))
+	for j, i := range is {
+		focals[j] = fr.hypotheses[i]
+	}
+	// Hypotheses were validated when added to the frame, so there's no need to
+	// go through K.
+	sort.Strings(focals)
+	return functionKey(strings.Join(focals, ","))
+}
+
+// universe returns the bitKey containing every hypothesis in the frame.
+func (fr *Frame) universe() (bk bitKey) {
+	n := fr.Len()
+	if n <= wordBits {
+		if n == wordBits {
+			return bitKey{lo: ^uint64(0)}
+		}
+		return bitKey{lo: 1<<uint(n) - 1}
+	}
+	ws := make([]uint64, (n-1)/wordBits)
+	for i := range ws {
+		ws[i] = ^uint64(0)
+	}
+	if r := n % wordBits; r != 0 {
+		ws[len(ws)-1] = 1<<uint(r) - 1
+	}
+	return bitKey{lo: ^uint64(0), hi: packWords(ws)}
+}
+
+// complement returns the bitKey containing every hypothesis in the frame that
+// is not part of the given bitKey.
+func (fr *Frame) complement(bk bitKey) bitKey {
+	return fr.universe().Difference(bk)
+}
+
+// maxDenseHypotheses is the largest frame whose subsets may all be
+// enumerated. Dense vectors over larger frames would hold tens of millions of
+// values or more.
+const maxDenseHypotheses = 24
+
+var errFrameTooLarge = fmt.Errorf(
+	"frame is too large to enumerate, limit is %d hypotheses", maxDenseHypotheses)
+
+// powersetSize returns the number of subsets of the frame. Returns an error if
+// the frame is too large for its subsets to be enumerated.
+func (fr *Frame) powersetSize() (int, error) {
+	n := fr.Len()
+	if n > maxDenseHypotheses {
+		return 0, errFrameTooLarge
+	}
+	return 1 << uint(n), nil
+}
+
+// powerset returns every subset of the frame as bitKeys, ordered so that each
+// subset appears after all of its own subsets. Returns nil if the frame is too
+// large for its subsets to be enumerated.
+func (fr *Frame) powerset() (bks []bitKey) {
+	size, err := fr.powersetSize()
+	if err != nil {
+		return nil
+	}
+	bks = make([]bitKey, 0, size)
+	for num := 0; num < size; num++ {
+		bks = append(bks, bitKey{lo: uint64(num)})
+	}
+	return
+}
+
+// translator returns a function that maps bitKeys over another frame to
+// bitKeys over this frame. Hypotheses that aren't part of this frame are
+// dropped.
+func (fr *Frame) translator(from *Frame) func(bitKey) bitKey {
+	identity := true
+	mapping := make([]int, from.Len())
+	for i := 0; i < from.Len(); i++ {
+		mapping[i] = fr.Index(from.hypotheses[i])
+		if mapping[i] != i {
+			identity = false
+		}
+	}
+	if identity {
+		return func(bk bitKey) bitKey { return bk }
+	}
+	return func(bk bitKey) (tbk bitKey) {
+		for _, i := range bk.Elements() {
+			if i < len(mapping) && mapping[i] >= 0 {
+				tbk = tbk.With(mapping[i])
+			}
+		}
+		return tbk
+	}
+}
+
+// Covers returns true if every focal element of the key is part of the frame.
+func (fr *Frame) Covers(fk functionKey) bool {
+	_, ok := fr.key(fk)
+	return ok
+}
+
+// Universe returns the key containing every hypothesis in the frame.
+func (fr *Frame) Universe() functionKey {
+	if fr == nil {
+		return K()
+	}
+	return fr.functionKey(fr.universe())
+}
+
+// Complement returns the key containing every hypothesis in the frame that is
+// not part of the given key.
+func (fr *Frame) Complement(fk functionKey) functionKey {
+	if fr == nil {
+		return K()
+	}
+	var bk bitKey
+	for _, focus := range fk.FocalElements() {
+		if i := fr.Index(string(focus)); i >= 0 {
+			bk = bk.With(i)
+		}
+	}
+	return fr.functionKey(fr.complement(bk))
+}
+
+// Powerset returns every subset of the frame. Returns nil if the frame is too
+// large for its subsets to be enumerated.
+func (fr *Frame) Powerset() (fks []functionKey) {
+	if fr == nil {
+		return []functionKey{K()}
+	}
+	bks := fr.powerset()
+	if bks == nil {
+		return nil
+	}
+	fks = make([]functionKey, len(bks))
+	for i, bk := range bks {
+		fks[i] = fr.functionKey(bk)
+	}
+	return
+}
+
+// Equal returns true if both frames contain exactly the same hypotheses,
+// regardless of order.
+func (fr *Frame) Equal(ofr *Frame) bool {
+	if fr.Len() != ofr.Len() {
+		return false
+	}
+	for i := 0; i < fr.Len(); i++ {
+		if !ofr.Contains(fr.hypotheses[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if every hypothesis in the frame is part of another
+// frame.
+func (fr *Frame) IsSubset(ofr *Frame) bool {
+	for i := 0; i < fr.Len(); i++ {
+		if !ofr.Contains(fr.hypotheses[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// String presents a human-readable version of the frame
+func (fr *Frame) String() string {
+	if fr == nil {
+		return "frame{}"
+	}
+	return fmt.Sprintf("frame{%s}", strings.Join(fr.hypotheses, ","))
+}
+
+var errFrameMismatch = errors.New("functions are defined over incompatible frames")
+
+// commonFrame reconciles the frames of a set of functions. Functions that were
+// explicitly bound to a frame must all agree on it and every other function
+// must fit within it. If no function is bound, the frames are merged in the
+// order the hypotheses were first observed. The returned bool indicates
+// whether the reconciled frame is a bound one.
+func commonFrame(fns ...*Function) (*Frame, bool, error) {
+	var bound *Frame
+	for _, f := range fns {
+		fr, fixed := f.frameInfo()
+		if !fixed {
+			continue
+		}
+		if bound == nil {
+			bound = fr
+		} else if !bound.Equal(fr) {
+			return nil, false, errFrameMismatch
+		}
+	}
+	if bound != nil {
+		for _, f := range fns {
+			fr, _ := f.frameInfo()
+			if !fr.IsSubset(bound) {
+				return nil, false, errFrameMismatch
+			}
+		}
+		return bound, true, nil
+	}
+	var merged *Frame
+	for _, f := range fns {
+		fr, _ := f.frameInfo()
+		merged = merged.extend(fr.Hypotheses()...)
+	}
+	return merged, false, nil
+}
+
+// fitsWithin returns true if the function could be combined with functions
+// bound to the given frame: a bound function must share the frame, while any
+// other function must only use hypotheses from it.
+func (fr *Frame) fitsWithin(f *Function) bool {
+	ffr, bound := f.frameInfo()
+	if bound {
+		return ffr.Equal(fr)
+	}
+	return ffr.IsSubset(fr)
+}
@@ -0,0 +1,133 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, err := NewFrame("red", "yellow", "green")
+	assert.Nil(err)
+	assert.Equal(3, fr.Len())
+	assert.Equal([]string{"red", "yellow", "green"}, fr.Hypotheses())
+	assert.Equal(0, fr.Index("red"))
+	assert.Equal(2, fr.Index("green"))
+	assert.Equal(-1, fr.Index("blue"))
+	assert.True(fr.Contains("yellow"))
+	assert.False(fr.Contains("blue"))
+	assert.Equal("frame{red,yellow,green}", fr.String())
+
+	// Mutating the returned hypotheses shouldn't affect the frame
+	hs := fr.Hypotheses()
+	hs[0] = "blue"
+	assert.Equal(0, fr.Index("red"))
+
+	_, err = NewFrame("red", "red")
+	assert.NotNil(err)
+	_, err = NewFrame("Red")
+	assert.NotNil(err)
+	_, err = NewFrame("")
+	assert.NotNil(err)
+
+	fr, err = NewFrame()
+	assert.Nil(err)
+	assert.Equal(0, fr.Len())
+	assert.Equal(K(), fr.Universe())
+}
+
+func TestFrameExtend(t *testing.T) {
+	assert := assert.New(t)
+
+	var fr *Frame
+	assert.Equal(0, fr.Len())
+	fr = fr.extend("b", "a")
+	assert.Equal([]string{"b", "a"}, fr.Hypotheses())
+	fr2 := fr.extend("a", "c", "c")
+	assert.Equal([]string{"b", "a", "c"}, fr2.Hypotheses())
+	// The original frame is left untouched
+	assert.Equal([]string{"b", "a"}, fr.Hypotheses())
+	// Nothing new to add returns the same frame
+	assert.True(fr == fr.extend("a"))
+}
+
+func TestFrameCovers(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("a", "b", "c")
+	assert.True(fr.Covers(K()))
+	assert.True(fr.Covers(K("a", "c")))
+	assert.True(fr.Covers(K("a", "b", "c")))
+	assert.False(fr.Covers(K("a", "d")))
+}
+
+func TestFrameComplement(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("a", "b", "c", "d")
+	assert.Equal(K("a", "b", "c", "d"), fr.Universe())
+	assert.Equal(K("c", "d"), fr.Complement(K("a", "b")))
+	assert.Equal(K(), fr.Complement(K("a", "b", "c", "d")))
+	assert.Equal(K("a", "b", "c", "d"), fr.Complement(K()))
+	assert.Equal(K("b", "c", "d"), fr.Complement(K("a", "e")))
+}
+
+func TestFramePowerset(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("a", "b", "c")
+	ps := fr.Powerset()
+	assert.Len(ps, 8)
+	assert.Contains(ps, K())
+	assert.Contains(ps, K("a"))
+	assert.Contains(ps, K("b"))
+	assert.Contains(ps, K("c"))
+	assert.Contains(ps, K("a", "b"))
+	assert.Contains(ps, K("a", "c"))
+	assert.Contains(ps, K("b", "c"))
+	assert.Contains(ps, K("a", "b", "c"))
+}
+
+func TestFrameEqual(t *testing.T) {
+	assert := assert.New(t)
+
+	fr1, _ := NewFrame("a", "b", "c")
+	fr2, _ := NewFrame("c", "b", "a")
+	fr3, _ := NewFrame("a", "b")
+	assert.True(fr1.Equal(fr2))
+	assert.False(fr1.Equal(fr3))
+	assert.True(fr3.IsSubset(fr1))
+	assert.False(fr1.IsSubset(fr3))
+}
+
+func TestCommonFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	abc, _ := NewFrame("a", "b", "c")
+	abd, _ := NewFrame("a", "b", "d")
+
+	unbound1 := &Function{}
+	unbound1.Set(K("a"), 0.5)
+	unbound2 := &Function{}
+	unbound2.Set(K("c", "b"), 0.5)
+	fr, bound, err := commonFrame(unbound1, unbound2)
+	assert.Nil(err)
+	assert.False(bound)
+	assert.True(fr.Equal(abc))
+
+	bound1 := &Function{}
+	bound1.bind(abc, true)
+	fr, bound, err = commonFrame(unbound1, bound1, unbound2)
+	assert.Nil(err)
+	assert.True(bound)
+	assert.True(fr == abc)
+
+	bound2 := &Function{}
+	bound2.bind(abd, true)
+	_, _, err = commonFrame(bound1, bound2)
+	assert.Equal(errFrameMismatch, err)
+	_, _, err = commonFrame(unbound2, bound2)
+	assert.Equal(errFrameMismatch, err)
+}
@@ -30,61 +30,74 @@ func (fk functionKey) FocalElements() (fks []functionKey) {
 
 // IsSubset returns true if a possibility is a subset of another possibility
 func (fk functionKey) IsSubset(ofk functionKey) bool {
-	count := 0
+	// Keys are always sorted, so a single merge pass is enough
 	fkfe := fk.FocalElements()
+	ofkfe := ofk.FocalElements()
+	j := 0
 	for _, a := range fkfe {
-		for _, b := range ofk.FocalElements() {
-			if a == b {
-				count++
-				break
-			}
+		for j < len(ofkfe) && ofkfe[j] < a {
+			j++
+		}
+		if j == len(ofkfe) || ofkfe[j] != a {
+			return false
 		}
 	}
-	return count == len(fkfe)
+	return true
 }
 
 // IsSuperset returns true if a possibility is a superset of another possibility
 func (fk functionKey) IsSuperset(ofk functionKey) bool {
-	count := 0
-	ofkfe := ofk.FocalElements()
-	for _, a := range ofkfe {
-		for _, b := range fk.FocalElements() {
-			if a == b {
-				count++
-				break
-			}
-		}
-	}
-	return count == len(ofkfe)
+	return ofk.IsSubset(fk)
 }
 
 // Intersect returns the functionKey that would be the intersection of the two
 // keys.
 func (fk functionKey) Intersect(ofk functionKey) (ifk functionKey) {
-	var intersectingKeys []string
+	fkfe := fk.FocalElements()
 	ofkfe := ofk.FocalElements()
-	for _, a := range ofkfe {
-		for _, b := range fk.FocalElements() {
-			if a == b {
-				intersectingKeys = append(intersectingKeys, string(a))
-				break
-			}
+	intersectingKeys := make([]string, 0, len(fkfe))
+	for i, j := 0, 0; i < len(fkfe) && j < len(ofkfe); {
+		switch {
+		case fkfe[i] < ofkfe[j]:
+			i++
+		case fkfe[i] > ofkfe[j]:
+			j++
+		default:
+			intersectingKeys = append(intersectingKeys, string(fkfe[i]))
+			i++
+			j++
 		}
 	}
-	return K(intersectingKeys...)
+	return functionKey(strings.Join(intersectingKeys, ","))
 }
 
 // Union returns the functionKey that would be the union of the two keys.
 func (fk functionKey) Union(ofk functionKey) (ifk functionKey) {
-	var unionedKeys []string
-	for _, a := range fk.FocalElements() {
-		unionedKeys = append(unionedKeys, string(a))
+	fkfe := fk.FocalElements()
+	ofkfe := ofk.FocalElements()
+	unionedKeys := make([]string, 0, len(fkfe)+len(ofkfe))
+	i, j := 0, 0
+	for i < len(fkfe) && j < len(ofkfe) {
+		switch {
+		case fkfe[i] < ofkfe[j]:
+			unionedKeys = append(unionedKeys, string(fkfe[i]))
+			i++
+		case fkfe[i] > ofkfe[j]:
+			unionedKeys = append(unionedKeys, string(ofkfe[j]))
+			j++
+		default:
+			unionedKeys = append(unionedKeys, string(fkfe[i]))
+			i++
+			j++
+		}
 	}
-	for _, b := range ofk.FocalElements() {
-		unionedKeys = append(unionedKeys, string(b))
+	for ; i < len(fkfe); i++ {
+		unionedKeys = append(unionedKeys, string(fkfe[i]))
 	}
-	// K takes care of dupes
-	return K(unionedKeys...)
+	for ; j < len(ofkfe); j++ {
+		unionedKeys = append(unionedKeys, string(ofkfe[j]))
+	}
+	return functionKey(strings.Join(unionedKeys, ","))
 }
 
 // Powerset returns all combinations of function keys within this function key.
@@ -134,24 +147,56 @@ func K(focals ...string) functionKey {
 }
 
 // A Function is a mapping of possibilities to values in the 0.0 to 1.0 range,
-// usually probabilities.
+// usually probabilities. A Function is defined over a Frame. Functions created
+// with a frame are bound to it, while the zero value starts with an empty frame
+// that grows to include every hypothesis it is given. Possibilities are stored
+// as bitmasks over the frame, so set operations on them are cheap.
 type Function struct {
-	focalSet      stringSet
-	possibilities map[functionKey]float64
+	frame         *Frame
+	bound         bool
+	possibilities map[bitKey]float64
 	mux           sync.Mutex
 }
 
-// TODO: Need a hash func for efficient equality testing and O(1) lookups
-
 func (f *Function) init() {
 	if f.possibilities == nil {
-		f.possibilities = make(map[functionKey]float64)
+		f.possibilities = make(map[bitKey]float64)
 	}
-	if f.focalSet == nil {
-		f.focalSet = make(stringSet)
+	if f.frame == nil {
+		f.frame = f.frame.extend()
 	}
 }
 
+// bind associates the function with a frame. A bound frame is fixed, while an
+// unbound frame grows as new hypotheses are set.
+func (f *Function) bind(frame *Frame, bound bool) {
+	f.frame = frame
+	f.bound = bound && frame != nil
+	f.init()
+}
+
+// frameInfo returns the function's frame and whether it is bound to it.
+func (f *Function) frameInfo() (frame *Frame, bound bool) {
+	f.mux.Lock()
+	f.init()
+	frame, bound = f.frame, f.bound
+	f.mux.Unlock()
+	return
+}
+
+// Frame returns the frame of discernment the function is defined over.
+func (f *Function) Frame() *Frame {
+	frame, _ := f.frameInfo()
+	return frame
+}
+
+// Bound returns true if the function was explicitly bound to its frame. Unbound
+// functions extend their frame whenever a new hypothesis is set.
+func (f *Function) Bound() bool {
+	_, bound := f.frameInfo()
+	return bound
+}
+
 // Set assigns a probability to a given possibility.
 func (f *Function) Set(key functionKey, probability float64) (err error) {
 	f.mux.Lock()
@@ -162,16 +207,36 @@ func (f *Function) Set(key functionKey, probability float64) (err error) {
 		f.mux.Unlock()
 		return errors.New("probability out of range")
 	}
+	bk, ok := f.frame.key(key)
+	if !ok && f.bound {
+		f.mux.Unlock()
+		return fmt.Errorf("possibility %s is not part of %s", key, f.frame)
+	}
+	if !ok {
+		focals := key.FocalElements()
+		hypotheses := make([]string, 0, len(focals))
+		for _, focus := range focals {
+			hypotheses = append(hypotheses, string(focus))
+		}
+		// New hypotheses are always appended to the frame, so the bitKeys that
+		// have already been stored remain valid.
+		f.frame = f.frame.extend(hypotheses...)
+		bk, _ = f.frame.key(key)
+	}
 	// Don't validate further as this can lead to difficulties when changing a
 	// mass function in-place. We're only validating the input in isolation.
-	f.possibilities[key] = probability
-	for _, focus := range key.FocalElements() {
-		f.focalSet[string(focus)] = exists
-	}
+	f.possibilities[bk] = probability
 	f.mux.Unlock()
 	return nil
 }
 
+// setUnsafe assigns a value to a possibility given as a bitKey over the
+// function's frame, truncating it the same way Set does.
+func (f *Function) setUnsafe(bk bitKey, value float64) {
+	f.init()
+	f.possibilities[bk] = floatFixed(value, 5)
+}
+
 // Get assigns a probability to a given possibility.
 func (f *Function) Get(key functionKey) (probability float64) {
 	f.mux.Lock()
@@ -183,14 +248,56 @@ func (f *Function) Get(key functionKey) (probability float64) {
 // Get assigns a probability to a given possibility.
 func (f *Function) getUnsafe(key functionKey) (probability float64) {
 	f.init()
+	bk, ok := f.frame.key(key)
+	if !ok {
+		// Possibilities outside of the frame can't have been assigned.
+		return 0.0
+	}
+	return f.value(bk)
+}
+
+// value returns the value of a possibility given as a bitKey over the
+// function's frame.
+func (f *Function) value(bk bitKey) (probability float64) {
 	var ok bool
-	if probability, ok = f.possibilities[key]; !ok {
+	if probability, ok = f.possibilities[bk]; !ok {
 		// If the key is missing, the probability is zero.
 		probability = 0.0
 	}
 	return
 }
 
+// A focal pairs a possibility, as a bitKey, with its non-zero value.
+type focal struct {
+	key   bitKey
+	value float64
+}
+
+// focalSets returns every possibility with a non-zero value, translated onto
+// the given frame and ordered by bitKey. The given frame must contain every
+// hypothesis in the function's own frame.
+func (f *Function) focalSets(frame *Frame) (fs []focal) {
+	f.mux.Lock()
+	fs = f.focalSetsUnsafe(frame)
+	f.mux.Unlock()
+	return
+}
+
+func (f *Function) focalSetsUnsafe(frame *Frame) (fs []focal) {
+	f.init()
+	translate := frame.translator(f.frame)
+	fs = make([]focal, 0, len(f.possibilities))
+	for bk, v := range f.possibilities {
+		if v != 0.0 {
+			fs = append(fs, focal{key: translate(bk), value: v})
+		}
+	}
+	sort.Slice(fs, func(i, j int) bool {
+		return fs[i].key.less(fs[j].key)
+	})
+	return fs
+}
+
 // sort.Interface for function key lists
 type fkList struct {
 	fks []functionKey
@@ -219,7 +326,7 @@ func (f *Function) Possibilities() (fks []functionKey) {
 	f.mux.Lock()
 	f.init()
 	for p := range f.possibilities {
-		fks = append(fks, p)
+		fks = append(fks, f.frame.functionKey(p))
 	}
 	fkl := fkList{
 		fks: fks,
@@ -234,7 +341,7 @@ func (f *Function) Select(fks []functionKey) (nf *Function) {
 	f.mux.Lock()
 	f.init()
 	nf = &Function{}
-	nf.init()
+	nf.bind(f.frame, f.bound)
 	for _, fk := range fks {
 		nf.Set(fk, f.getUnsafe(fk))
 	}
@@ -242,26 +349,12 @@ func (f *Function) Select(fks []functionKey) (nf *Function) {
 	return nf
 }
 
-// Powerset returns all combinations of function keys for this Function.
+// Powerset returns all combinations of function keys for this Function's
+// frame. Returns nil if the frame is too large to enumerate.
 func (f *Function) Powerset() (fks []functionKey) {
 	f.mux.Lock()
 	f.init()
-	focals := make([]string, 0, len(f.focalSet))
-	for focal := range f.focalSet {
-		focals = append(focals, focal)
-	}
-	n := len(focals)
-	for num := 0; num < (1 << uint(n)); num++ {
-		combination := []string{}
-		for i := 0; i < n; i++ {
-			// bit set
-			if num&(1<<uint(i)) != 0 {
-				// append to the combination
-				combination = append(combination, focals[i])
-			}
-		}
-		fks = append(fks, K(combination...))
-	}
+	fks = f.frame.Powerset()
 	f.mux.Unlock()
 	return
 }
@@ -1,6 +1,7 @@
 package evidence
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -9,6 +10,14 @@ import (
 // A MassFunction is a mapping of possibilities to probabilities.
 type MassFunction struct {
 	Function
+	openWorld bool
+}
+
+// NewMassFunction creates an empty MassFunction bound to the given frame.
+func NewMassFunction(frame *Frame) *MassFunction {
+	mf := &MassFunction{}
+	mf.bind(frame, true)
+	return mf
 }
 
 func (mf *MassFunction) String() string {
@@ -16,18 +25,46 @@ func (mf *MassFunction) String() string {
 	bf := mf.Belief()
 	pf := mf.Plausibility()
 	for _, p := range mf.Possibilities() {
+		// Frames too large to enumerate have no beliefs or plausibilities
+		if bf == nil || pf == nil {
+			sb.WriteString(fmt.Sprintf("%s\t%f\n", p, mf.Get(p)))
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("%s\t%f\t%f\t%f\n",
 			p, mf.Get(p), bf.Get(p), pf.Get(p)))
 	}
 	return sb.String()
 }
 
+// SetOpenWorld configures whether the MassFunction follows the open-world
+// assumption of Smets' Transferable Belief Model, in which mass assigned to the
+// empty set represents conflict or a hypothesis outside of the frame.
+func (mf *MassFunction) SetOpenWorld(openWorld bool) {
+	mf.mux.Lock()
+	mf.openWorld = openWorld
+	mf.mux.Unlock()
+}
+
+// OpenWorld returns true if the MassFunction follows the open-world
+// assumption.
+func (mf *MassFunction) OpenWorld() bool {
+	mf.mux.Lock()
+	openWorld := mf.openWorld
+	mf.mux.Unlock()
+	return openWorld
+}
+
 // Valid verifies that a given MassFunction meets the defined requirements for
 // one. All probabilities must be in the range 0.0 >= p >= 1.0, and all
-// probabilities must ultimately sum to 1.0.
+// probabilities must ultimately sum to 1.0. Unless the MassFunction follows the
+// open-world assumption, no mass may be assigned to the empty set.
 func (mf *MassFunction) Valid() bool {
 	mf.mux.Lock()
 	mf.init()
+	if !mf.openWorld && mf.value(bitKey{}) != 0.0 {
+		mf.mux.Unlock()
+		return false
+	}
 	var sum float64
 	for _, probability := range mf.possibilities {
 		if probability < 0.0 || probability > 1.0 {
@@ -45,10 +82,11 @@ func (mf *MassFunction) Valid() bool {
 	return true
 }
 
-// FocalKeys returns a slice containing just the focal keys
+// FocalKeys returns a slice containing a singleton key for each hypothesis in
+// the frame.
 func (mf *MassFunction) FocalKeys() (fks []functionKey) {
-	for k := range mf.focalSet {
-		fks = append(fks, functionKey(k))
+	for _, h := range mf.Frame().Hypotheses() {
+		fks = append(fks, K(h))
 	}
 	return fks
 }
@@ -58,109 +96,107 @@ func (mf *MassFunction) Focals() (f *Function) {
 	return mf.Select(mf.FocalKeys())
 }
 
-// Belief converts a MassFunction into a BeliefFunction
+// Belief converts a MassFunction into a BeliefFunction. Any mass assigned to
+// the empty set is included, so for an open-world MassFunction this is Smets'
+// implicability function. Returns nil if the frame is too large to enumerate.
 func (mf *MassFunction) Belief() (bf *BeliefFunction) {
-	fks := mf.Powerset()
-	mf.mux.Lock()
-	bf = &BeliefFunction{}
-	bf.init()
-	// Iterate over all keys in the mass function's powerset
-	for _, p := range fks {
-		value := 0.0
-		// Then iterate over all keys in that key's powerset, summing their values
-		for _, k := range p.Powerset() {
-			value += mf.getUnsafe(k)
-		}
-		bf.Set(p, value)
+	v, frame, bound, err := mf.dense()
+	if err != nil {
+		return nil
 	}
-	mf.mux.Unlock()
+	bf = &BeliefFunction{}
+	bf.bind(frame, bound)
+	// The belief in each key is the sum of the masses of all of its subsets
+	zetaSubsets(v)
+	bf.setDenseUnsafe(v, false)
 	return
 }
 
-// Plausibility converts a MassFunction into a PlausibilityFunction
+// Plausibility converts a MassFunction into a PlausibilityFunction. Returns nil
+// if the frame is too large to enumerate.
 func (mf *MassFunction) Plausibility() (pf *PlausibilityFunction) {
-	fks := mf.Powerset()
-	bf := mf.Belief()
-	mf.mux.Lock()
-	pf = &PlausibilityFunction{}
-	pf.init()
-	// Iterate over all keys in the mass function's powerset
-	for _, p := range fks {
-		// ~p is the focals that don't make up proposition p
-		// i.e. if p = a,b and the any-set is a,b,c,d, then ~p is c,d
-		notP := make([]string, 0)
-		for _, k := range mf.FocalKeys() {
-			inP := false
-			for _, k2 := range p.FocalElements() {
-				if k == k2 {
-					inP = true
-					break
-				}
-			}
-			if !inP {
-				notP = append(notP, string(k))
-			}
-		}
-		fk := K(notP...)
-		pf.Set(p, 1.0-bf.getUnsafe(fk))
+	v, frame, bound, err := mf.dense()
+	if err != nil {
+		return nil
 	}
-	mf.mux.Unlock()
+	pf = &PlausibilityFunction{}
+	pf.bind(frame, bound)
+	zetaSubsets(v)
+	// ~p is the hypotheses in the frame that don't make up proposition p
+	// i.e. if p = a,b and the frame is a,b,c,d, then ~p is c,d, and the
+	// plausibility of p is 1.0 - the belief in ~p
+	pf.setDenseUnsafe(dualize(v), false)
 	return
 }
 
-// Commonality converts a MassFunction into a CommonalityFunction
+// Commonality converts a MassFunction into a CommonalityFunction. Returns nil
+// if the frame is too large to enumerate.
 func (mf *MassFunction) Commonality() (cf *CommonalityFunction) {
-	fks := mf.Powerset()
-	mf.mux.Lock()
+	v, frame, bound, err := mf.dense()
+	if err != nil {
+		return nil
+	}
 	cf = &CommonalityFunction{}
-	cf.init()
-	// Iterate over all keys in the mass function's powerset
-	for _, p := range fks {
-		value := 0.0
-		for _, k := range fks {
-			if p.IsSubset(k) {
-				value += mf.getUnsafe(k)
-			}
+	cf.bind(frame, bound)
+	// The commonality of each key is the sum of the masses of all of its
+	// supersets
+	zetaSupersets(v)
+	cf.setDenseUnsafe(v, false)
+	return
+}
+
+// Normalize returns a new MassFunction with any mass assigned to the empty set
+// removed and the remaining masses scaled up to compensate, as Dempster's rule
+// of combination would. The new MassFunction follows the closed-world
+// assumption. Returns an error if all of the mass is assigned to the empty set.
+func (mf *MassFunction) Normalize() (nmf *MassFunction, err error) {
+	mf.mux.Lock()
+	defer mf.mux.Unlock()
+	mf.init()
+	conflict := mf.value(bitKey{})
+	if conflict >= 1.0 {
+		return nil, errors.New("cannot normalize a completely conflicting mass function")
+	}
+	nmf = &MassFunction{}
+	nmf.bind(mf.frame, mf.bound)
+	for p, v := range mf.possibilities {
+		if !p.IsEmpty() {
+			nmf.setUnsafe(p, v/(1.0-conflict))
 		}
-		cf.Set(p, value)
 	}
-	mf.mux.Unlock()
-	return
+	nmf.setUnsafe(bitKey{}, 0.0)
+	return nmf, nil
 }
 
 // Pignistic returns a new MassFunction after application of the pignistic
 // transformation containing only singletons.
 func (mf *MassFunction) Pignistic() (nmf *MassFunction) {
-	fks := mf.Powerset()
 	mf.mux.Lock()
 	nmf = &MassFunction{}
-	for _, p := range fks {
-		v := mf.getUnsafe(p)
-		if v > 0.0 {
-			pfe := p.FocalElements()
-			size := float64(len(pfe))
-			for _, s := range pfe {
-				nmf.Set(s, nmf.getUnsafe(s)+(v/size))
-			}
+	nmf.bind(mf.frame, mf.bound)
+	betP := make(map[int]float64)
+	for _, f := range mf.focalSetsUnsafe(mf.frame) {
+		elements := f.key.Elements()
+		size := float64(len(elements))
+		for _, i := range elements {
+			betP[i] += f.value / size
 		}
 	}
+	for i, v := range betP {
+		nmf.setUnsafe(singletonBitKey(i), v)
+	}
 	mf.mux.Unlock()
 	return
 }
 
-// Entropy returns the Deng entropy for the MassFunction.
+// Entropy returns the Deng entropy for the MassFunction. Mass assigned to the
+// empty set is ignored.
 func (mf *MassFunction) Entropy() float64 {
 	entropy := 0.0
-	fks := mf.Powerset()
-	mf.mux.Lock()
-	for _, p := range fks {
-		v := mf.getUnsafe(p)
-		if floatEq(v, 0.0) {
-			continue
-		}
-		n := len(p.FocalElements())
+	for _, f := range mf.nonEmptyFocalSets() {
+		v := f.value
+		n := f.key.Len()
 		entropy -= v * math.Log2(v/(math.Pow(2.0, float64(n))-1.0))
 	}
-	mf.mux.Unlock()
 	return entropy
 }
@@ -18,18 +18,18 @@ func TestAccessors(t *testing.T) {
 	mf.Set(K("a"), 0.0)
 	assert.Equal(0.0, mf.Get(K("a")))
 	assert.False(mf.Valid())
-	assert.True(mf.focalSet.Contains("a"))
+	assert.True(mf.Frame().Contains("a"))
 	mf.Set(K("b"), 0.1)
 	assert.Equal(0.1, mf.Get(K("b")))
 	assert.False(mf.Valid())
-	assert.True(mf.focalSet.Contains("b"))
+	assert.True(mf.Frame().Contains("b"))
 	// Set something non-zero
 	mf.Set(K("a", "b", "c"), 0.4)
 	// Adding something new shouldn't change what we already had
 	assert.Equal(0.0, mf.Get(K("a")))
 	assert.Equal(0.4, mf.Get(K("a", "b", "c")))
 	assert.False(mf.Valid())
-	assert.True(mf.focalSet.Contains("c"))
+	assert.True(mf.Frame().Contains("c"))
 	// Setting an invalid value should give an error and leave value unchanged
 	err := mf.Set(K("a", "b", "c"), 4.0)
 	assert.NotNil(err)
@@ -39,7 +39,83 @@ func TestAccessors(t *testing.T) {
 	mf.Set(K("b", "c"), 0.5)
 	assert.True(mf.Valid())
 	// Make sure that checking for things that don't exist also works
-	assert.False(mf.focalSet.Contains("d"))
+	assert.False(mf.Frame().Contains("d"))
+}
+
+func TestNewMassFunction(t *testing.T) {
+	assert := assert.New(t)
+
+	fr, _ := NewFrame("a", "b", "c")
+	mf := NewMassFunction(fr)
+	assert.True(mf.Bound())
+	assert.True(mf.Frame() == fr)
+	assert.Nil(mf.Set(K("a", "b"), 0.6))
+	// Possibilities outside of the frame are rejected
+	assert.NotNil(mf.Set(K("a", "d"), 0.4))
+	assert.Equal(0.0, mf.Get(K("a", "d")))
+	assert.False(mf.Frame().Contains("d"))
+	assert.Nil(mf.Set(K("c"), 0.4))
+	assert.True(mf.Valid())
+
+	// Hypotheses that never receive mass are still part of the frame
+	bf := mf.Belief()
+	assert.True(bf.Frame() == fr)
+	assert.Equal(0.6, bf.Get(K("a", "b")))
+	pf := mf.Plausibility()
+	assert.True(pf.Frame() == fr)
+	assert.InDelta(0.6, pf.Get(K("a")), 0.00001)
+	assert.InDelta(0.4, pf.Get(K("c")), 0.00001)
+	assert.InDelta(1.0, pf.Get(K("a", "c")), 0.00001)
+
+	unbound := &MassFunction{}
+	unbound.Set(K("a", "b"), 0.6)
+	unbound.Set(K("c"), 0.4)
+	assert.False(unbound.Bound())
+	assert.True(unbound.Frame().Equal(fr))
+}
+
+func TestOpenWorld(t *testing.T) {
+	assert := assert.New(t)
+
+	mf := &MassFunction{}
+	mf.Set(K(), 0.2)
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("a", "b"), 0.3)
+	assert.False(mf.OpenWorld())
+	// Mass on the empty set is only acceptable under the open-world assumption
+	assert.False(mf.Valid())
+	mf.SetOpenWorld(true)
+	assert.True(mf.OpenWorld())
+	assert.True(mf.Valid())
+	mf.Set(K("a", "b"), 0.4)
+	assert.False(mf.Valid())
+}
+
+func TestNormalize(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	fr, _ := NewFrame("a", "b")
+	mf := NewMassFunction(fr)
+	mf.SetOpenWorld(true)
+	mf.Set(K(), 0.2)
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("a", "b"), 0.3)
+	nmf, err := mf.Normalize()
+	assert.Nil(err)
+	assert.True(nmf.Frame() == fr)
+	assert.False(nmf.OpenWorld())
+	assert.InDelta(0.0, nmf.Get(K()), tolerance)
+	assert.InDelta(0.625, nmf.Get(K("a")), tolerance)
+	assert.InDelta(0.375, nmf.Get(K("a", "b")), tolerance)
+	assert.True(nmf.Valid())
+	// The original is left untouched
+	assert.InDelta(0.2, mf.Get(K()), tolerance)
+
+	mf = &MassFunction{}
+	mf.Set(K(), 1.0)
+	_, err = mf.Normalize()
+	assert.NotNil(err)
 }
 
 func TestFocals(t *testing.T) {
@@ -74,6 +150,7 @@ func TestBelief(t *testing.T) {
 	mf.Set(K("a", "b", "c"), 0.1)
 	mf.Set(K("b", "c"), 0.5)
 	bf := mf.Belief()
+	assert.Len(bf.Possibilities(), 8)
 	assert.Equal(0.0, bf.Get(K()))
 	assert.Equal(0.0, bf.Get(K("a")))
 	assert.Equal(0.1, bf.Get(K("b")))
@@ -248,6 +325,14 @@ func TestEntropy(t *testing.T) {
 	mf.Set(K("a", "b", "c", "d", "e"), 1.0)
 
 	assert.InDelta(4.9541, mf.Entropy(), tolerance)
+
+	// Mass assigned to the empty set is ignored
+	mf = &MassFunction{}
+	mf.SetOpenWorld(true)
+	mf.Set(K(), 0.2)
+	mf.Set(K("a"), 0.8)
+
+	assert.InDelta(0.25754, mf.Entropy(), tolerance)
 }
 
 func BenchmarkEntropy(b *testing.B) {
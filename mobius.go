@@ -0,0 +1,162 @@
+package evidence
+
+// The conversions between mass, belief, plausibility and commonality functions
+// are all instances of the zeta transform, or its inverse, the Möbius
+// transform, over the lattice of subsets of a frame. Working on a dense vector
+// indexed by each subset's bitmask, these can be computed one hypothesis at a
+// time in O(n·2^n) rather than the O(4^n) of summing over every pair of
+// subsets.
+
+// zetaSubsets replaces each value with the sum of the values of all of its
+// subsets.
+func zetaSubsets(v []float64) {
+	for bit := 1; bit < len(v); bit <<= 1 {
+		for i := range v {
+			if i&bit != 0 {
+				v[i] += v[i^bit]
+			}
+		}
+	}
+}
+
+// mobiusSubsets is the inverse of zetaSubsets.
+func mobiusSubsets(v []float64) {
+	for bit := 1; bit < len(v); bit <<= 1 {
+		for i := range v {
+			if i&bit != 0 {
+				v[i] -= v[i^bit]
+			}
+		}
+	}
+}
+
+// zetaSupersets replaces each value with the sum of the values of all of its
+// supersets.
+func zetaSupersets(v []float64) {
+	for bit := 1; bit < len(v); bit <<= 1 {
+		for i := range v {
+			if i&bit == 0 {
+				v[i] += v[i|bit]
+			}
+		}
+	}
+}
+
+// mobiusSupersets is the inverse of zetaSupersets.
+func mobiusSupersets(v []float64) {
+	for bit := 1; bit < len(v); bit <<= 1 {
+		for i := range v {
+			if i&bit == 0 {
+				v[i] -= v[i|bit]
+			}
+		}
+	}
+}
+
+// dualize maps each value onto the complement of its subset, taking it away
+// from 1.0. This converts between belief and plausibility in either direction.
+func dualize(v []float64) []float64 {
+	universe := len(v) - 1
+	dv := make([]float64, len(v))
+	for i := range v {
+		dv[i] = 1.0 - v[universe^i]
+	}
+	return dv
+}
+
+// denseUnsafe returns the function's values as a vector indexed by the bitmask
+// of each possibility over the function's frame. Returns an error if the frame
+// is too large to enumerate.
+func (f *Function) denseUnsafe() ([]float64, error) {
+	f.init()
+	size, err := f.frame.powersetSize()
+	if err != nil {
+		return nil, err
+	}
+	v := make([]float64, size)
+	for bk, value := range f.possibilities {
+		v[bk.lo] = value
+	}
+	return v, nil
+}
+
+// dense returns the function's values as a vector indexed by the bitmask of
+// each possibility, along with the frame they're indexed over. Returns an error
+// if the frame is too large to enumerate.
+func (f *Function) dense() (v []float64, frame *Frame, bound bool, err error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	v, err = f.denseUnsafe()
+	return v, f.frame, f.bound, err
+}
+
+// denseOver returns the function's values as a vector indexed by the bitmask
+// of each possibility over the given frame, which must contain every hypothesis
+// in the function's own frame. Returns an error if the frame is too large to
+// enumerate.
+func (f *Function) denseOver(frame *Frame) ([]float64, error) {
+	size, err := frame.powersetSize()
+	if err != nil {
+		return nil, err
+	}
+	v := make([]float64, size)
+	for _, fs := range f.focalSets(frame) {
+		v[fs.key.lo] = fs.value
+	}
+	return v, nil
+}
+
+// setDenseUnsafe assigns every value in a vector indexed by bitmask over the
+// function's frame. If sparse is true, values that are zero once truncated are
+// left unassigned.
+func (f *Function) setDenseUnsafe(v []float64, sparse bool) {
+	for i, value := range v {
+		if sparse && floatFixed(value, 5) == 0.0 {
+			continue
+		}
+		f.setUnsafe(bitKey{lo: uint64(i)}, value)
+	}
+}
+
+// Mass converts a BeliefFunction back into a MassFunction. Returns nil if the
+// frame is too large to enumerate.
+func (bf *BeliefFunction) Mass() (mf *MassFunction) {
+	v, frame, bound, err := bf.dense()
+	if err != nil {
+		return nil
+	}
+	mf = &MassFunction{}
+	mf.bind(frame, bound)
+	mobiusSubsets(v)
+	mf.setDenseUnsafe(v, true)
+	return
+}
+
+// Mass converts a PlausibilityFunction back into a MassFunction. Returns nil if
+// the frame is too large to enumerate.
+func (pf *PlausibilityFunction) Mass() (mf *MassFunction) {
+	v, frame, bound, err := pf.dense()
+	if err != nil {
+		return nil
+	}
+	v = dualize(v)
+	mf = &MassFunction{}
+	mf.bind(frame, bound)
+	mobiusSubsets(v)
+	mf.setDenseUnsafe(v, true)
+	return
+}
+
+// Mass converts a CommonalityFunction back into a MassFunction. Returns nil if
+// the frame is too large to enumerate.
+func (cf *CommonalityFunction) Mass() (mf *MassFunction) {
+	v, frame, bound, err := cf.dense()
+	if err != nil {
+		return nil
+	}
+	mf = &MassFunction{}
+	mf.bind(frame, bound)
+	mobiusSupersets(v)
+	mf.setDenseUnsafe(v, true)
+	return
+}
@@ -0,0 +1,182 @@
+package evidence
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func trafficLight() *MassFunction {
+	mf := &MassFunction{}
+	mf.Set(K(), 0.0)
+	mf.Set(K("red"), 0.35)
+	mf.Set(K("yellow"), 0.25)
+	mf.Set(K("green"), 0.15)
+	mf.Set(K("red", "yellow"), 0.06)
+	mf.Set(K("red", "green"), 0.05)
+	mf.Set(K("yellow", "green"), 0.04)
+	mf.Set(K("red", "yellow", "green"), 0.1)
+	return mf
+}
+
+func TestMobiusTransforms(t *testing.T) {
+	assert := assert.New(t)
+
+	v := []float64{0.0, 0.1, 0.2, 0.3, 0.05, 0.05, 0.1, 0.2}
+	orig := append([]float64{}, v...)
+	zetaSubsets(v)
+	assert.InDelta(1.0, v[7], 0.00001)
+	assert.InDelta(0.6, v[3], 0.00001)
+	mobiusSubsets(v)
+	assert.InDeltaSlice(orig, v, 0.00001)
+	zetaSupersets(v)
+	assert.InDelta(1.0, v[0], 0.00001)
+	assert.InDelta(0.8, v[2], 0.00001)
+	mobiusSupersets(v)
+	assert.InDeltaSlice(orig, v, 0.00001)
+}
+
+func TestRoundTrips(t *testing.T) {
+	const tolerance = 0.00005
+
+	tcs := []struct {
+		name    string
+		convert func(*MassFunction) *MassFunction
+	}{
+		{
+			name: "belief",
+			convert: func(mf *MassFunction) *MassFunction {
+				return mf.Belief().Mass()
+			},
+		},
+		{
+			name: "plausibility",
+			convert: func(mf *MassFunction) *MassFunction {
+				return mf.Plausibility().Mass()
+			},
+		},
+		{
+			name: "commonality",
+			convert: func(mf *MassFunction) *MassFunction {
+				return mf.Commonality().Mass()
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mf := trafficLight()
+			rmf := tc.convert(mf)
+			assert.True(rmf.Frame() == mf.Frame())
+			for _, p := range mf.Powerset() {
+				assert.InDelta(mf.Get(p), rmf.Get(p), tolerance, p.String())
+			}
+			assert.True(rmf.Valid())
+		})
+	}
+}
+
+func TestFastTransformsMatchDefinitions(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00005
+
+	mf := &MassFunction{}
+	mf.Set(K("a"), 0.1)
+	mf.Set(K("b", "d"), 0.15)
+	mf.Set(K("a", "c", "e"), 0.2)
+	mf.Set(K("b", "c", "d", "e"), 0.25)
+	mf.Set(K("a", "b", "c", "d", "e", "f"), 0.3)
+	bf := mf.Belief()
+	pf := mf.Plausibility()
+	cf := mf.Commonality()
+	for _, p := range mf.Powerset() {
+		bel, pl, q := 0.0, 0.0, 0.0
+		for _, f := range mf.Possibilities() {
+			if f.IsSubset(p) {
+				bel += mf.Get(f)
+			}
+			if f.Intersect(p) != K() {
+				pl += mf.Get(f)
+			}
+			if f.IsSuperset(p) {
+				q += mf.Get(f)
+			}
+		}
+		assert.InDelta(bel, bf.Get(p), tolerance, p.String())
+		assert.InDelta(pl, pf.Get(p), tolerance, p.String())
+		assert.InDelta(q, cf.Get(p), tolerance, p.String())
+	}
+}
+
+func TestMassFromBelief(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	fr, _ := NewFrame("alive", "dead")
+	bf := NewBeliefFunction(fr)
+	bf.Set(K(), 0.0)
+	bf.Set(K("alive"), 0.2)
+	bf.Set(K("dead"), 0.5)
+	bf.Set(K("alive", "dead"), 1.0)
+	mf := bf.Mass()
+	assert.True(mf.Bound())
+	assert.InDelta(0.2, mf.Get(K("alive")), tolerance)
+	assert.InDelta(0.5, mf.Get(K("dead")), tolerance)
+	assert.InDelta(0.3, mf.Get(K("alive", "dead")), tolerance)
+	assert.True(mf.Valid())
+}
+
+func BenchmarkBelief(b *testing.B) {
+	hypotheses := make([]string, 16)
+	for i := range hypotheses {
+		hypotheses[i] = fmt.Sprintf("h%d", i)
+	}
+	fr, _ := NewFrame(hypotheses...)
+	mf := NewMassFunction(fr)
+	mf.Set(K("h1", "h2"), 0.3)
+	mf.Set(K("h3"), 0.3)
+	mf.Set(fr.Universe(), 0.4)
+
+	for n := 0; n < b.N; n++ {
+		mf.Belief()
+	}
+}
+
+// largeFrame returns a frame with too many hypotheses for its subsets to be
+// enumerated.
+func largeFrame() (*Frame, []string) {
+	hypotheses := make([]string, maxDenseHypotheses+6)
+	for i := range hypotheses {
+		hypotheses[i] = fmt.Sprintf("h%d", i)
+	}
+	frame, _ := NewFrame(hypotheses...)
+	return frame, hypotheses
+}
+
+func TestDenseLargeFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	frame, hypotheses := largeFrame()
+	mf := NewMassFunction(frame)
+	assert.Nil(mf.Set(K("h0"), 0.5))
+	assert.Nil(mf.Set(K(hypotheses...), 0.5))
+
+	assert.Nil(mf.Belief())
+	assert.Nil(mf.Plausibility())
+	assert.Nil(mf.Commonality())
+	assert.Nil(NewBeliefFunction(frame).Mass())
+	assert.Nil(NewPlausibilityFunction(frame).Mass())
+	assert.Nil(NewCommonalityFunction(frame).Mass())
+	assert.Nil(frame.Powerset())
+	assert.Nil(mf.Powerset())
+	// Only the masses are printed
+	assert.Equal("{h0}\t0.500000\n", strings.SplitAfter(mf.String(), "\n")[0])
+
+	// The MassFunction remains usable
+	assert.InDelta(0.5, mf.Get(K("h0")), 0.00001)
+	assert.InDelta(0.5*math.Log2(float64(len(hypotheses))), mf.Nonspecificity(), 0.00001)
+}
@@ -0,0 +1,321 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// A Network is a valuation-based system for reasoning with evidence about
+// several Variables at once. Each piece of evidence, whether a prior, a
+// relation between Variables or an observation, is a valuation: a
+// MassFunction over the product frame of the Variables it concerns. Rather
+// than combining every valuation on the product frame of all of the Variables,
+// which grows exponentially with their number, the Network arranges them in a
+// join tree and computes marginals by Shenoy and Shafer's local computation,
+// only ever combining MassFunctions over the Variables of a single node of the
+// tree.
+type Network struct {
+	variables  []*Variable
+	valuations []*MassFunction
+	// tree is built when first needed and discarded whenever the valuations
+	// change
+	tree *joinTree
+	mux  sync.Mutex
+}
+
+// A joinTree is a tree of cliques of Variables in which every clique that
+// shares a Variable with another is connected to it by a path of cliques that
+// all contain that Variable.
+type joinTree struct {
+	cliques []*clique
+	// messages caches the message sent from one clique to a neighbor
+	messages map[[2]int]*MassFunction
+}
+
+// A clique is a node of a joinTree.
+type clique struct {
+	variables []string
+	frame     *Frame
+	potential *MassFunction
+	neighbors []int
+}
+
+// NewNetwork creates an empty Network over the given Variables. Returns an
+// error if two Variables share a name.
+func NewNetwork(variables ...*Variable) (*Network, error) {
+	n := &Network{}
+	for _, v := range variables {
+		if n.variable(v.name) != nil {
+			return nil, fmt.Errorf("duplicate variable name (%q)", v.name)
+		}
+		n.variables = append(n.variables, v)
+	}
+	return n, nil
+}
+
+// variable returns the named Variable, or nil if it isn't part of the Network.
+func (n *Network) variable(name string) *Variable {
+	for _, v := range n.variables {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// Variables returns the Network's Variables in order.
+func (n *Network) Variables() []*Variable {
+	vs := make([]*Variable, len(n.variables))
+	copy(vs, n.variables)
+	return vs
+}
+
+// AddValuation adds a piece of evidence to the Network, as a MassFunction over
+// the product frame of the Variables it concerns. Returns an error if the
+// MassFunction isn't defined over a product frame or if its Variables don't
+// match the Network's.
+func (n *Network) AddValuation(mf *MassFunction) error {
+	frame := mf.Frame()
+	if frame.Variables() == nil {
+		return fmt.Errorf("%s is not a product frame", frame)
+	}
+	for _, v := range frame.variables {
+		nv := n.variable(v.name)
+		if nv == nil {
+			return fmt.Errorf("variable %q is not part of the network", v.name)
+		}
+		if !nv.values.Equal(v.values) {
+			return fmt.Errorf("variable %q has different values in %s", v.name, frame)
+		}
+	}
+	n.mux.Lock()
+	n.valuations = append(n.valuations, mf)
+	n.tree = nil
+	n.mux.Unlock()
+	return nil
+}
+
+// Marginal returns the marginal MassFunction of the combination of every
+// valuation in the Network on the given Variables, normalized according to
+// Dempster's rule. The Variables must all appear together in a single
+// valuation, unless only one Variable is given. Returns an error if any of the
+// Variables aren't part of the Network, if they don't appear together in a
+// valuation, or if the valuations are entirely contradictory.
+func (n *Network) Marginal(variables ...string) (*MassFunction, error) {
+	if len(variables) == 0 {
+		return nil, errors.New("no variables to marginalize onto")
+	}
+	for _, name := range variables {
+		if n.variable(name) == nil {
+			return nil, fmt.Errorf("variable %q is not part of the network", name)
+		}
+	}
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if n.tree == nil {
+		tree, err := n.buildJoinTree()
+		if err != nil {
+			return nil, err
+		}
+		n.tree = tree
+	}
+	for i, c := range n.tree.cliques {
+		if !containsAll(c.variables, variables) {
+			continue
+		}
+		mf, err := n.tree.collect(i, -1).Marginalize(variables...)
+		if err != nil {
+			return nil, err
+		}
+		return mf.Normalize()
+	}
+	return nil, fmt.Errorf("variables %v do not appear together in any valuation",
+		variables)
+}
+
+// containsAll returns true if every name in subset is part of the sorted set.
+func containsAll(set []string, subset []string) bool {
+	for _, name := range subset {
+		i := sort.SearchStrings(set, name)
+		if i == len(set) || set[i] != name {
+			return false
+		}
+	}
+	return true
+}
+
+// buildJoinTree arranges the Network's valuations into a join tree by
+// eliminating one Variable at a time. Eliminating a Variable merges the
+// domains of every valuation that concerns it into a clique, and the clique,
+// less the eliminated Variable, becomes a new domain. Variables are eliminated
+// greedily, choosing whichever produces the clique with the smallest product
+// frame.
+func (n *Network) buildJoinTree() (*joinTree, error) {
+	// Every Variable gets a domain of its own, so that it can be queried even
+	// if no valuation concerns it
+	var domains []map[string]bool
+	for _, v := range n.variables {
+		domains = append(domains, map[string]bool{v.name: true})
+	}
+	for _, mf := range n.valuations {
+		domain := make(map[string]bool)
+		for _, v := range mf.Frame().variables {
+			domain[v.name] = true
+		}
+		domains = append(domains, domain)
+	}
+
+	var cliques [][]string
+	eliminated := make(map[string]int)
+	remaining := make([]string, len(n.variables))
+	for i, v := range n.variables {
+		remaining[i] = v.name
+	}
+	sort.Strings(remaining)
+	for len(remaining) > 0 {
+		best, bestSize := 0, -1
+		for i, name := range remaining {
+			size := 1
+			for member := range mergedDomain(domains, name) {
+				size *= n.variable(member).values.Len()
+			}
+			if bestSize < 0 || size < bestSize {
+				best, bestSize = i, size
+			}
+		}
+		name := remaining[best]
+		remaining = append(remaining[:best], remaining[best+1:]...)
+		merged := mergedDomain(domains, name)
+		var kept []map[string]bool
+		for _, domain := range domains {
+			if !domain[name] {
+				kept = append(kept, domain)
+			}
+		}
+		variables := make([]string, 0, len(merged))
+		separator := make(map[string]bool)
+		for member := range merged {
+			variables = append(variables, member)
+			if member != name {
+				separator[member] = true
+			}
+		}
+		sort.Strings(variables)
+		eliminated[name] = len(cliques)
+		cliques = append(cliques, variables)
+		domains = append(kept, separator)
+	}
+
+	tree := &joinTree{
+		cliques:  make([]*clique, len(cliques)),
+		messages: make(map[[2]int]*MassFunction),
+	}
+	for i, variables := range cliques {
+		vs := make([]*Variable, len(variables))
+		for j, name := range variables {
+			vs[j] = n.variable(name)
+		}
+		frame, err := NewProductFrame(vs...)
+		if err != nil {
+			return nil, err
+		}
+		tree.cliques[i] = &clique{variables: variables, frame: frame}
+	}
+	// Each clique is connected to the clique that eliminated the first of its
+	// other Variables, which always contains all of them
+	for i, c := range tree.cliques {
+		parent := -1
+		for _, name := range c.variables {
+			if j := eliminated[name]; j != i && (parent < 0 || j < parent) {
+				parent = j
+			}
+		}
+		if parent >= 0 {
+			c.neighbors = append(c.neighbors, parent)
+			tree.cliques[parent].neighbors = append(tree.cliques[parent].neighbors, i)
+		}
+	}
+	// Each valuation belongs to the clique that eliminated the first of its
+	// Variables, which contains all of them
+	assigned := make([][]*MassFunction, len(cliques))
+	for _, mf := range n.valuations {
+		first := -1
+		for _, v := range mf.Frame().variables {
+			if j := eliminated[v.name]; first < 0 || j < first {
+				first = j
+			}
+		}
+		extended, err := mf.Extend(tree.cliques[first].frame)
+		if err != nil {
+			return nil, err
+		}
+		assigned[first] = append(assigned[first], extended)
+	}
+	for i, c := range tree.cliques {
+		c.potential = combineUnnormalized(c.frame, assigned[i]...)
+	}
+	return tree, nil
+}
+
+// mergedDomain returns the union of every domain that contains the named
+// Variable.
+func mergedDomain(domains []map[string]bool, name string) map[string]bool {
+	merged := map[string]bool{name: true}
+	for _, domain := range domains {
+		if domain[name] {
+			for member := range domain {
+				merged[member] = true
+			}
+		}
+	}
+	return merged
+}
+
+// combineUnnormalized returns the unnormalized conjunctive combination of
+// MassFunctions over the given frame as a new open-world MassFunction. With no
+// MassFunctions, the result is vacuous.
+func combineUnnormalized(frame *Frame, mfns ...*MassFunction) *MassFunction {
+	cf := NewMassFunction(frame)
+	cf.openWorld = true
+	for p, v := range conjunctiveMasses(frame, mfns...) {
+		cf.setUnsafe(p, v)
+	}
+	return cf
+}
+
+// collect combines a clique's potential with the messages from each of its
+// neighbors other than the excluded one, over the clique's frame.
+func (t *joinTree) collect(i int, exclude int) *MassFunction {
+	c := t.cliques[i]
+	mfns := []*MassFunction{c.potential}
+	for _, j := range c.neighbors {
+		if j == exclude {
+			continue
+		}
+		extended, _ := t.message(j, i).Extend(c.frame)
+		mfns = append(mfns, extended)
+	}
+	return combineUnnormalized(c.frame, mfns...)
+}
+
+// message returns the message sent from one clique to a neighbor: everything
+// known on the sender's side of the tree, marginalized onto the Variables the
+// two cliques share.
+func (t *joinTree) message(from int, to int) *MassFunction {
+	key := [2]int{from, to}
+	if m, ok := t.messages[key]; ok {
+		return m
+	}
+	var shared []string
+	for _, name := range t.cliques[from].variables {
+		if containsAll(t.cliques[to].variables, []string{name}) {
+			shared = append(shared, name)
+		}
+	}
+	// Neighboring cliques always share at least one Variable
+	m, _ := t.collect(from, to).Marginalize(shared...)
+	t.messages[key] = m
+	return m
+}
@@ -0,0 +1,173 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// diagnosticNetwork models a machine whose fault causes an alarm and a
+// temperature reading, with a separate sensor on the temperature.
+func diagnosticNetwork() (*Network, []*MassFunction) {
+	fault, _ := NewVariable("fault", "yes", "no")
+	alarm, _ := NewVariable("alarm", "on", "off")
+	temperature, _ := NewVariable("temperature", "hot", "normal")
+	sensor, _ := NewVariable("sensor", "high", "low")
+	network, _ := NewNetwork(fault, alarm, temperature, sensor)
+
+	faults, _ := NewProductFrame(fault)
+	prior := NewMassFunction(faults)
+	prior.Set(K("yes"), 0.1)
+	prior.Set(K("yes", "no"), 0.9)
+
+	// A fault usually sets off the alarm
+	fa, _ := NewProductFrame(fault, alarm)
+	faultAlarm := NewMassFunction(fa)
+	faultAlarm.Set(K("off-no", "on-no", "on-yes"), 0.8)
+	faultAlarm.Set(fa.Universe(), 0.2)
+
+	// A fault usually makes the machine hot, and it's rarely hot otherwise
+	ft, _ := NewProductFrame(fault, temperature)
+	faultTemperature := NewMassFunction(ft)
+	faultTemperature.Set(K("no-hot", "no-normal", "yes-hot"), 0.7)
+	faultTemperature.Set(K("no-normal", "yes-hot"), 0.2)
+	faultTemperature.Set(ft.Universe(), 0.1)
+
+	// The sensor is fairly reliable
+	ts, _ := NewProductFrame(temperature, sensor)
+	temperatureSensor := NewMassFunction(ts)
+	temperatureSensor.Set(K("high-hot", "low-normal"), 0.9)
+	temperatureSensor.Set(ts.Universe(), 0.1)
+
+	// The alarm went off and the sensor reads high
+	alarms, _ := NewProductFrame(alarm)
+	alarmOn := NewMassFunction(alarms)
+	alarmOn.Set(K("on"), 1.0)
+	sensors, _ := NewProductFrame(sensor)
+	sensorHigh := NewMassFunction(sensors)
+	sensorHigh.Set(K("high"), 0.8)
+	sensorHigh.Set(K("high", "low"), 0.2)
+
+	valuations := []*MassFunction{
+		prior, faultAlarm, faultTemperature, temperatureSensor, alarmOn, sensorHigh,
+	}
+	for _, mf := range valuations {
+		network.AddValuation(mf)
+	}
+	return network, valuations
+}
+
+func TestNetworkMarginal(t *testing.T) {
+	const tolerance = 0.0001
+
+	network, valuations := diagnosticNetwork()
+
+	// Combining everything on the full joint frame gives the same marginals,
+	// only much less efficiently
+	joint, _ := NewProductFrame(network.Variables()...)
+	extended := make([]*MassFunction, len(valuations))
+	for i, mf := range valuations {
+		extended[i], _ = mf.Extend(joint)
+	}
+	combined := CombineConjunctive(extended...)
+
+	tcs := [][]string{
+		{"fault"},
+		{"alarm"},
+		{"temperature"},
+		{"sensor"},
+		{"fault", "temperature"},
+		{"sensor", "temperature"},
+	}
+	for _, variables := range tcs {
+		t.Run(variables[0], func(t *testing.T) {
+			assert := assert.New(t)
+			mf, err := network.Marginal(variables...)
+			assert.Nil(err)
+			expected, _ := combined.Marginalize(variables...)
+			assert.True(mf.Frame().Equal(expected.Frame()))
+			for _, p := range expected.Frame().Powerset() {
+				assert.InDelta(expected.Get(p), mf.Get(p), tolerance, p.String())
+			}
+			assert.True(mf.Valid())
+		})
+	}
+}
+
+func TestNetworkUpdates(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	network, _ := diagnosticNetwork()
+	before, err := network.Marginal("fault")
+	assert.Nil(err)
+	assert.True(before.Get(K("yes")) > 0.1)
+
+	// Learning that there's definitely no fault overrides everything else
+	fault := network.Variables()[0]
+	faults, _ := NewProductFrame(fault)
+	noFault := NewMassFunction(faults)
+	noFault.Set(K("no"), 1.0)
+	assert.Nil(network.AddValuation(noFault))
+	after, err := network.Marginal("fault")
+	assert.Nil(err)
+	assert.InDelta(1.0, after.Get(K("no")), tolerance)
+}
+
+func TestNetworkErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	network, _ := diagnosticNetwork()
+	_, err := network.Marginal()
+	assert.NotNil(err)
+	_, err = network.Marginal("pressure")
+	assert.NotNil(err)
+	_, err = network.Marginal("alarm", "sensor")
+	assert.NotNil(err)
+
+	assert.NotNil(network.AddValuation(trafficLight()))
+	pressure, _ := NewVariable("pressure", "high", "low")
+	pressures, _ := NewProductFrame(pressure)
+	assert.NotNil(network.AddValuation(NewMassFunction(pressures)))
+	alarm, _ := NewVariable("alarm", "on", "off", "broken")
+	alarms, _ := NewProductFrame(alarm)
+	assert.NotNil(network.AddValuation(NewMassFunction(alarms)))
+
+	// Contradictory evidence can't be normalized
+	fault := network.Variables()[0]
+	faults, _ := NewProductFrame(fault)
+	yes := NewMassFunction(faults)
+	yes.Set(K("yes"), 1.0)
+	no := NewMassFunction(faults)
+	no.Set(K("no"), 1.0)
+	network.AddValuation(yes)
+	network.AddValuation(no)
+	_, err = network.Marginal("alarm")
+	assert.NotNil(err)
+
+	fault2, _ := NewVariable("fault", "yes", "no")
+	_, err = NewNetwork(fault, fault2)
+	assert.NotNil(err)
+}
+
+func TestNetworkUnrelatedVariable(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	a, _ := NewVariable("a", "x", "y")
+	b, _ := NewVariable("b", "x", "y")
+	network, _ := NewNetwork(a, b)
+	as, _ := NewProductFrame(a)
+	mf := NewMassFunction(as)
+	mf.Set(K("x"), 0.4)
+	mf.Set(K("x", "y"), 0.6)
+	network.AddValuation(mf)
+
+	am, err := network.Marginal("a")
+	assert.Nil(err)
+	assert.InDelta(0.4, am.Get(K("x")), tolerance)
+	// Nothing is known about b
+	bm, err := network.Marginal("b")
+	assert.Nil(err)
+	assert.InDelta(1.0, bm.Get(K("x", "y")), tolerance)
+}
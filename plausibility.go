@@ -5,6 +5,13 @@ type PlausibilityFunction struct {
 	Function
 }
 
+// NewPlausibilityFunction creates an empty PlausibilityFunction bound to the given frame.
+func NewPlausibilityFunction(frame *Frame) *PlausibilityFunction {
+	pf := &PlausibilityFunction{}
+	pf.bind(frame, true)
+	return pf
+}
+
 // Valid verifies that a given PlausibilityFunction meets the defined
 // requirements for one. All probabilities must be in the range 0.0 >= p >= 1.0.
 func (pf *PlausibilityFunction) Valid() bool {
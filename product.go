@@ -0,0 +1,270 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Variable is a named quantity whose value is one of the hypotheses of its
+// frame, e.g. a location, an object type or a threat level.
+type Variable struct {
+	name   string
+	values *Frame
+}
+
+// NewVariable creates a Variable with the given name that takes one of the
+// given values. The name and values must be valid focus key names.
+func NewVariable(name string, values ...string) (*Variable, error) {
+	if !keyValidator.MatchString(name) {
+		return nil, fmt.Errorf(
+			"invalid variable name (%q), must be lowercase alphanumeric or hyphen", name)
+	}
+	fr, err := NewFrame(values...)
+	if err != nil {
+		return nil, err
+	}
+	if fr.Len() == 0 {
+		return nil, fmt.Errorf("variable %q has no values", name)
+	}
+	return &Variable{name: name, values: fr}, nil
+}
+
+// Name returns the name of the Variable.
+func (v *Variable) Name() string {
+	return v.name
+}
+
+// Values returns the frame of values the Variable may take.
+func (v *Variable) Values() *Frame {
+	return v.values
+}
+
+// String presents a human-readable version of the Variable
+func (v *Variable) String() string {
+	return fmt.Sprintf("%s{%s}", v.name, strings.Join(v.values.hypotheses, ","))
+}
+
+// NewProductFrame creates a Frame whose hypotheses are the configurations of
+// several Variables, i.e. every combination of one value of each Variable.
+// Focal sets over a product frame are therefore relations between the
+// Variables. The Variables are ordered by name, so the same Variables always
+// produce the same frame. Each configuration is named by joining the values of
+// the Variables with a hyphen, e.g. "paris-car", so a product frame of a
+// single Variable has the same hypotheses as the Variable itself. Returns an
+// error if no Variables are given, if two Variables share a name, or if two
+// configurations would share a name.
+func NewProductFrame(variables ...*Variable) (*Frame, error) {
+	if len(variables) == 0 {
+		return nil, errors.New("product frames need at least one variable")
+	}
+	vs := make([]*Variable, len(variables))
+	copy(vs, variables)
+	sort.Slice(vs, func(i, j int) bool {
+		return vs[i].name < vs[j].name
+	})
+	size := 1
+	for i, v := range vs {
+		if i > 0 && vs[i-1].name == v.name {
+			return nil, fmt.Errorf("duplicate variable name (%q)", v.name)
+		}
+		size *= v.values.Len()
+	}
+	fr := &Frame{
+		hypotheses: make([]string, size),
+		index:      make(map[string]int, size),
+		variables:  vs,
+	}
+	values := make([]string, len(vs))
+	for i := 0; i < size; i++ {
+		for j, value := range fr.configuration(i) {
+			values[j] = vs[j].values.hypotheses[value]
+		}
+		name := strings.Join(values, "-")
+		if _, ok := fr.index[name]; ok {
+			return nil, fmt.Errorf("configuration name %q is ambiguous", name)
+		}
+		fr.hypotheses[i] = name
+		fr.index[name] = i
+	}
+	return fr, nil
+}
+
+// Variables returns the Variables of a product frame in order, or nil if the
+// frame is not a product frame.
+func (fr *Frame) Variables() []*Variable {
+	if fr == nil || fr.variables == nil {
+		return nil
+	}
+	vs := make([]*Variable, len(fr.variables))
+	copy(vs, fr.variables)
+	return vs
+}
+
+// variable returns the position of the named Variable in a product frame, or
+// -1 if the frame has no such Variable.
+func (fr *Frame) variable(name string) int {
+	if fr == nil {
+		return -1
+	}
+	for i, v := range fr.variables {
+		if v.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// configuration returns the position of each Variable's value in the i-th
+// hypothesis of a product frame. The last Variable varies fastest.
+func (fr *Frame) configuration(i int) []int {
+	values := make([]int, len(fr.variables))
+	for j := len(fr.variables) - 1; j >= 0; j-- {
+		n := fr.variables[j].values.Len()
+		values[j] = i % n
+		i /= n
+	}
+	return values
+}
+
+// Configuration returns the hypothesis of a product frame in which each
+// Variable takes the given value. Returns an error if the frame is not a
+// product frame or if the values don't name exactly one valid value for each
+// of its Variables.
+func (fr *Frame) Configuration(values map[string]string) (string, error) {
+	if fr.Variables() == nil {
+		return "", fmt.Errorf("%s is not a product frame", fr)
+	}
+	if len(values) != len(fr.variables) {
+		return "", fmt.Errorf("expected values for %d variables, got %d",
+			len(fr.variables), len(values))
+	}
+	i := 0
+	for _, v := range fr.variables {
+		value, ok := values[v.name]
+		if !ok {
+			return "", fmt.Errorf("no value for variable %q", v.name)
+		}
+		j := v.values.Index(value)
+		if j < 0 {
+			return "", fmt.Errorf("%q is not a value of %s", value, v)
+		}
+		i = i*v.values.Len() + j
+	}
+	return fr.hypotheses[i], nil
+}
+
+// projector returns a function that maps the position of each hypothesis of a
+// product frame onto the position of its projection onto another product
+// frame, whose Variables must all be part of this one.
+func (fr *Frame) projector(to *Frame) (func(int) int, error) {
+	if fr.Variables() == nil || to.Variables() == nil {
+		return nil, errors.New("projection requires product frames")
+	}
+	positions := make([]int, len(to.variables))
+	mappings := make([][]int, len(to.variables))
+	for j, v := range to.variables {
+		positions[j] = fr.variable(v.name)
+		if positions[j] < 0 {
+			return nil, fmt.Errorf("variable %q is not part of %s", v.name, fr)
+		}
+		from := fr.variables[positions[j]].values
+		if !from.Equal(v.values) {
+			return nil, fmt.Errorf("variable %q has different values in %s", v.name, fr)
+		}
+		mappings[j] = make([]int, from.Len())
+		for k, value := range from.hypotheses {
+			mappings[j][k] = v.values.Index(value)
+		}
+	}
+	return func(i int) int {
+		values := fr.configuration(i)
+		projected := 0
+		for j, v := range to.variables {
+			projected = projected*v.values.Len() + mappings[j][values[positions[j]]]
+		}
+		return projected
+	}, nil
+}
+
+// Marginalize returns the marginal of a MassFunction over a product frame on a
+// subset of its Variables. The mass of each focal set is assigned to its
+// projection, the set of configurations of the given Variables that are part
+// of at least one of its configurations. The marginal is bound to the product
+// frame of the given Variables. Returns an error if the MassFunction isn't
+// defined over a product frame or if any of the Variables aren't part of it.
+func (mf *MassFunction) Marginalize(variables ...string) (*MassFunction, error) {
+	frame, _ := mf.frameInfo()
+	if frame.Variables() == nil {
+		return nil, fmt.Errorf("%s is not a product frame", frame)
+	}
+	vs := make([]*Variable, len(variables))
+	for i, name := range variables {
+		j := frame.variable(name)
+		if j < 0 {
+			return nil, fmt.Errorf("variable %q is not part of %s", name, frame)
+		}
+		vs[i] = frame.variables[j]
+	}
+	to, err := NewProductFrame(vs...)
+	if err != nil {
+		return nil, err
+	}
+	project, err := frame.projector(to)
+	if err != nil {
+		return nil, err
+	}
+	masses := make(map[bitKey]float64)
+	for _, f := range mf.focalSets(frame) {
+		var projected bitKey
+		for _, i := range f.key.Elements() {
+			projected = projected.With(project(i))
+		}
+		masses[projected] += f.value
+	}
+	mmf := NewMassFunction(to)
+	mmf.openWorld = mf.OpenWorld()
+	for p, v := range masses {
+		mmf.setUnsafe(p, v)
+	}
+	return mmf, nil
+}
+
+// Extend returns the vacuous extension of a MassFunction over a product frame
+// onto a larger product frame. The mass of each focal set is assigned to its
+// cylindrical extension, the set of configurations of the larger frame whose
+// projection is part of the focal set, so nothing is assumed about the
+// Variables that the MassFunction didn't cover. Returns an error if either
+// frame isn't a product frame or if the MassFunction's Variables aren't all
+// part of the larger frame.
+func (mf *MassFunction) Extend(to *Frame) (*MassFunction, error) {
+	frame, _ := mf.frameInfo()
+	if frame.Variables() == nil {
+		return nil, fmt.Errorf("%s is not a product frame", frame)
+	}
+	project, err := to.projector(frame)
+	if err != nil {
+		return nil, err
+	}
+	// The cylinder over each configuration of the smaller frame is every
+	// configuration of the larger frame that projects onto it
+	cylinders := make([]bitKey, frame.Len())
+	for i := 0; i < to.Len(); i++ {
+		j := project(i)
+		cylinders[j] = cylinders[j].With(i)
+	}
+	emf := NewMassFunction(to)
+	emf.openWorld = mf.OpenWorld()
+	for _, f := range mf.focalSets(frame) {
+		var extended bitKey
+		for _, i := range f.key.Elements() {
+			extended = extended.Union(cylinders[i])
+		}
+		// Extension is injective, so distinct focal sets extend to distinct
+		// possibilities, though these overlap wherever the focal sets do, and
+		// there's nothing to accumulate
+		emf.setUnsafe(extended, f.value)
+	}
+	return emf, nil
+}
@@ -0,0 +1,170 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func surveillanceVariables() (*Variable, *Variable, *Variable) {
+	location, _ := NewVariable("location", "paris", "lyon")
+	kind, _ := NewVariable("kind", "car", "truck")
+	threat, _ := NewVariable("threat", "low", "high")
+	return location, kind, threat
+}
+
+func TestNewVariable(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := NewVariable("kind", "car", "truck")
+	assert.Nil(err)
+	assert.Equal("kind", v.Name())
+	assert.Equal([]string{"car", "truck"}, v.Values().Hypotheses())
+	assert.Equal("kind{car,truck}", v.String())
+
+	_, err = NewVariable("Kind", "car")
+	assert.NotNil(err)
+	_, err = NewVariable("kind", "car", "car")
+	assert.NotNil(err)
+	_, err = NewVariable("kind")
+	assert.NotNil(err)
+}
+
+func TestNewProductFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	location, kind, threat := surveillanceVariables()
+	fr, err := NewProductFrame(location, kind)
+	assert.Nil(err)
+	// Variables are ordered by name
+	assert.Equal([]*Variable{kind, location}, fr.Variables())
+	assert.Equal([]string{"car-paris", "car-lyon", "truck-paris", "truck-lyon"},
+		fr.Hypotheses())
+	other, _ := NewProductFrame(kind, location)
+	assert.True(fr.Equal(other))
+
+	h, err := fr.Configuration(map[string]string{"location": "lyon", "kind": "truck"})
+	assert.Nil(err)
+	assert.Equal("truck-lyon", h)
+	_, err = fr.Configuration(map[string]string{"location": "lyon"})
+	assert.NotNil(err)
+	_, err = fr.Configuration(map[string]string{"location": "nice", "kind": "truck"})
+	assert.NotNil(err)
+	_, err = fr.Configuration(map[string]string{"location": "lyon", "threat": "low"})
+	assert.NotNil(err)
+
+	fr, err = NewProductFrame(threat)
+	assert.Nil(err)
+	assert.Equal([]string{"low", "high"}, fr.Hypotheses())
+
+	_, err = NewProductFrame()
+	assert.NotNil(err)
+	_, err = NewProductFrame(kind, kind)
+	assert.NotNil(err)
+	a, _ := NewVariable("a", "x-y", "x")
+	b, _ := NewVariable("b", "z", "y-z")
+	_, err = NewProductFrame(a, b)
+	assert.NotNil(err)
+
+	plain, _ := NewFrame("a", "b")
+	assert.Nil(plain.Variables())
+	_, err = plain.Configuration(map[string]string{"a": "b"})
+	assert.NotNil(err)
+}
+
+func TestMarginalize(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	location, kind, _ := surveillanceVariables()
+	fr, _ := NewProductFrame(location, kind)
+	mf := NewMassFunction(fr)
+	mf.Set(K("car-paris", "truck-lyon"), 0.5)
+	mf.Set(K("car-paris"), 0.2)
+	mf.Set(K("car-lyon", "car-paris"), 0.1)
+	mf.Set(fr.Universe(), 0.2)
+
+	lmf, err := mf.Marginalize("location")
+	assert.Nil(err)
+	assert.Equal([]*Variable{location}, lmf.Frame().Variables())
+	assert.InDelta(0.2, lmf.Get(K("paris")), tolerance)
+	assert.InDelta(0.8, lmf.Get(K("paris", "lyon")), tolerance)
+	assert.True(lmf.Valid())
+
+	kmf, err := mf.Marginalize("kind")
+	assert.Nil(err)
+	assert.InDelta(0.3, kmf.Get(K("car")), tolerance)
+	assert.InDelta(0.7, kmf.Get(K("car", "truck")), tolerance)
+	assert.True(kmf.Valid())
+
+	// Marginalizing onto every variable changes nothing
+	same, err := mf.Marginalize("location", "kind")
+	assert.Nil(err)
+	for _, p := range fr.Powerset() {
+		assert.InDelta(mf.Get(p), same.Get(p), tolerance, p.String())
+	}
+
+	_, err = mf.Marginalize("threat")
+	assert.NotNil(err)
+	_, err = trafficLight().Marginalize("location")
+	assert.NotNil(err)
+}
+
+func TestExtend(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	location, kind, threat := surveillanceVariables()
+	small, _ := NewProductFrame(kind)
+	large, _ := NewProductFrame(location, kind, threat)
+	mf := NewMassFunction(small)
+	mf.Set(K("car"), 0.7)
+	mf.Set(K("car", "truck"), 0.3)
+
+	emf, err := mf.Extend(large)
+	assert.Nil(err)
+	assert.True(emf.Frame() == large)
+	assert.InDelta(0.7, emf.Get(K(
+		"car-lyon-high", "car-lyon-low", "car-paris-high", "car-paris-low")), tolerance)
+	assert.InDelta(0.3, emf.Get(large.Universe()), tolerance)
+	assert.True(emf.Valid())
+
+	// Extending and marginalizing again gets back to where we started
+	rmf, err := emf.Marginalize("kind")
+	assert.Nil(err)
+	for _, p := range small.Powerset() {
+		assert.InDelta(mf.Get(p), rmf.Get(p), tolerance, p.String())
+	}
+
+	unrelated, _ := NewVariable("speed", "slow", "fast")
+	other, _ := NewProductFrame(location, unrelated)
+	_, err = mf.Extend(other)
+	assert.NotNil(err)
+	plain, _ := NewFrame("car", "truck")
+	_, err = mf.Extend(plain)
+	assert.NotNil(err)
+}
+
+func TestProductInference(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// Trucks are never seen in Paris, and we're fairly sure we've seen a truck
+	location, kind, _ := surveillanceVariables()
+	joint, _ := NewProductFrame(location, kind)
+	rule := NewMassFunction(joint)
+	rule.Set(K("car-paris", "car-lyon", "truck-lyon"), 1.0)
+	kinds, _ := NewProductFrame(kind)
+	observation := NewMassFunction(kinds)
+	observation.Set(K("truck"), 0.8)
+	observation.Set(K("car", "truck"), 0.2)
+
+	extended, err := observation.Extend(joint)
+	assert.Nil(err)
+	combined := CombineConjunctive(rule, extended)
+	assert.NotNil(combined)
+	lmf, err := combined.Marginalize("location")
+	assert.Nil(err)
+	assert.InDelta(0.8, lmf.Get(K("lyon")), tolerance)
+	assert.InDelta(0.2, lmf.Get(K("paris", "lyon")), tolerance)
+}
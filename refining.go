@@ -0,0 +1,165 @@
+package evidence
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A Refining relates a coarse Frame to a finer one by mapping each coarse
+// hypothesis onto a non-empty subset of the fine frame. The subsets must be
+// disjoint and together make up the whole fine frame, so that each fine
+// hypothesis refines exactly one coarse hypothesis. MassFunctions can then be
+// moved between the two frames, e.g. so that evidence about a "vehicle" can be
+// combined with evidence about a "car", "truck" or "bus".
+type Refining struct {
+	coarse *Frame
+	fine   *Frame
+	// refinement holds the subset of the fine frame for each coarse
+	// hypothesis, by position
+	refinement []bitKey
+	// coarsening holds the position of the coarse hypothesis for each fine
+	// hypothesis, by position
+	coarsening []int
+}
+
+// NewRefining creates a Refining from a coarse frame to a fine frame, given
+// the fine hypotheses that each coarse hypothesis is refined into. Returns an
+// error if a hypothesis is missing from its frame, if a coarse hypothesis
+// isn't refined into anything, or if the refinements overlap or leave part of
+// the fine frame out.
+func NewRefining(coarse *Frame, fine *Frame,
+	refinement map[string][]string) (*Refining, error) {
+	r := &Refining{
+		coarse:     coarse,
+		fine:       fine,
+		refinement: make([]bitKey, coarse.Len()),
+		coarsening: make([]int, fine.Len()),
+	}
+	for i := range r.coarsening {
+		r.coarsening[i] = -1
+	}
+	// Iterate in a fixed order so that errors are reported consistently
+	hypotheses := make([]string, 0, len(refinement))
+	for h := range refinement {
+		hypotheses = append(hypotheses, h)
+	}
+	sort.Strings(hypotheses)
+	for _, h := range hypotheses {
+		i := coarse.Index(h)
+		if i < 0 {
+			return nil, fmt.Errorf("hypothesis %q is not part of %s", h, coarse)
+		}
+		for _, fh := range refinement[h] {
+			j := fine.Index(fh)
+			if j < 0 {
+				return nil, fmt.Errorf("hypothesis %q is not part of %s", fh, fine)
+			}
+			if r.coarsening[j] >= 0 {
+				return nil, fmt.Errorf("hypothesis %q refines both %q and %q",
+					fh, coarse.hypotheses[r.coarsening[j]], h)
+			}
+			r.coarsening[j] = i
+			r.refinement[i] = r.refinement[i].With(j)
+		}
+	}
+	for i, bk := range r.refinement {
+		if bk.IsEmpty() {
+			return nil, fmt.Errorf("hypothesis %q is not refined", coarse.hypotheses[i])
+		}
+	}
+	for j, i := range r.coarsening {
+		if i < 0 {
+			return nil, fmt.Errorf("hypothesis %q does not refine any hypothesis",
+				fine.hypotheses[j])
+		}
+	}
+	return r, nil
+}
+
+// Coarse returns the coarse frame of the Refining.
+func (r *Refining) Coarse() *Frame {
+	return r.coarse
+}
+
+// Fine returns the fine frame of the Refining.
+func (r *Refining) Fine() *Frame {
+	return r.fine
+}
+
+// refine maps a possibility over the coarse frame onto the fine frame.
+func (r *Refining) refine(bk bitKey) (fbk bitKey) {
+	for _, i := range bk.Elements() {
+		fbk = fbk.Union(r.refinement[i])
+	}
+	return fbk
+}
+
+// outer maps a possibility over the fine frame onto every coarse hypothesis
+// whose refinement it overlaps.
+func (r *Refining) outer(bk bitKey) (cbk bitKey) {
+	for _, j := range bk.Elements() {
+		cbk = cbk.With(r.coarsening[j])
+	}
+	return cbk
+}
+
+// inner maps a possibility over the fine frame onto every coarse hypothesis
+// whose refinement it contains entirely.
+func (r *Refining) inner(bk bitKey) (cbk bitKey) {
+	for i, rbk := range r.refinement {
+		if rbk.IsSubset(bk) {
+			cbk = cbk.With(i)
+		}
+	}
+	return cbk
+}
+
+// move transfers the mass of each focal set of a MassFunction over one frame
+// of the Refining onto a new MassFunction bound to the other frame.
+func (r *Refining) move(mf *MassFunction, from *Frame, to *Frame,
+	mapping func(bitKey) bitKey) (*MassFunction, error) {
+	if !from.fitsWithin(&mf.Function) {
+		return nil, fmt.Errorf("mass function over %s does not fit within %s",
+			mf.Frame(), from)
+	}
+	masses := make(map[bitKey]float64)
+	for _, f := range mf.focalSets(from) {
+		masses[mapping(f.key)] += f.value
+	}
+	mmf := NewMassFunction(to)
+	for p, v := range masses {
+		mmf.setUnsafe(p, v)
+	}
+	mmf.openWorld = mf.OpenWorld() || mmf.value(bitKey{}) != 0.0
+	return mmf, nil
+}
+
+// Refine returns the vacuous refinement of a MassFunction over the coarse
+// frame onto the fine frame, in which the mass of each focal set is assigned
+// to its refinement. Nothing is assumed about how the mass is distributed
+// within each refinement, so the result is no more informative than the
+// original. Returns an error if the MassFunction's frame doesn't fit within
+// the coarse frame.
+func (r *Refining) Refine(mf *MassFunction) (*MassFunction, error) {
+	return r.move(mf, r.coarse, r.fine, r.refine)
+}
+
+// CoarsenOuter returns the outer reduction of a MassFunction over the fine
+// frame onto the coarse frame, in which the mass of each focal set is assigned
+// to every coarse hypothesis whose refinement it overlaps. The result is the
+// most informative MassFunction over the coarse frame that is no more
+// committed than the original. Returns an error if the MassFunction's frame
+// doesn't fit within the fine frame.
+func (r *Refining) CoarsenOuter(mf *MassFunction) (*MassFunction, error) {
+	return r.move(mf, r.fine, r.coarse, r.outer)
+}
+
+// CoarsenInner returns the inner reduction of a MassFunction over the fine
+// frame onto the coarse frame, in which the mass of each focal set is assigned
+// to every coarse hypothesis whose refinement it contains entirely. Mass on
+// focal sets that don't contain any refinement is assigned to the empty set,
+// and the new MassFunction then follows the open-world assumption. Returns an
+// error if the MassFunction's frame doesn't fit within the fine frame.
+func (r *Refining) CoarsenInner(mf *MassFunction) (*MassFunction, error) {
+	return r.move(mf, r.fine, r.coarse, r.inner)
+}
@@ -0,0 +1,158 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func vehicleRefining() *Refining {
+	coarse, _ := NewFrame("vehicle", "person")
+	fine, _ := NewFrame("car", "truck", "bus", "pedestrian")
+	r, _ := NewRefining(coarse, fine, map[string][]string{
+		"vehicle": {"car", "truck", "bus"},
+		"person":  {"pedestrian"},
+	})
+	return r
+}
+
+func TestNewRefining(t *testing.T) {
+	assert := assert.New(t)
+
+	r := vehicleRefining()
+	assert.NotNil(r)
+	assert.Equal([]string{"vehicle", "person"}, r.Coarse().Hypotheses())
+	assert.Equal(4, r.Fine().Len())
+
+	coarse, _ := NewFrame("vehicle", "person")
+	fine, _ := NewFrame("car", "truck", "pedestrian")
+	tcs := []struct {
+		name       string
+		refinement map[string][]string
+	}{
+		{
+			name: "unknown coarse hypothesis",
+			refinement: map[string][]string{
+				"vehicle": {"car", "truck"},
+				"person":  {"pedestrian"},
+				"animal":  {},
+			},
+		},
+		{
+			name: "unknown fine hypothesis",
+			refinement: map[string][]string{
+				"vehicle": {"car", "truck", "bus"},
+				"person":  {"pedestrian"},
+			},
+		},
+		{
+			name: "overlapping",
+			refinement: map[string][]string{
+				"vehicle": {"car", "truck"},
+				"person":  {"pedestrian", "truck"},
+			},
+		},
+		{
+			name: "not refined",
+			refinement: map[string][]string{
+				"vehicle": {"car", "truck", "pedestrian"},
+			},
+		},
+		{
+			name: "not covered",
+			refinement: map[string][]string{
+				"vehicle": {"car"},
+				"person":  {"pedestrian"},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRefining(coarse, fine, tc.refinement)
+			assert.Nil(r)
+			assert.NotNil(err)
+		})
+	}
+}
+
+func TestRefine(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	r := vehicleRefining()
+	coarse := NewMassFunction(r.Coarse())
+	coarse.Set(K("vehicle"), 0.7)
+	coarse.Set(K("vehicle", "person"), 0.3)
+	refined, err := r.Refine(coarse)
+	assert.Nil(err)
+	assert.True(refined.Frame() == r.Fine())
+	assert.InDelta(0.7, refined.Get(K("car", "truck", "bus")), tolerance)
+	assert.InDelta(0.3, refined.Get(K("car", "truck", "bus", "pedestrian")), tolerance)
+	assert.True(refined.Valid())
+
+	// Once refined, evidence of different granularity can be combined
+	fine := NewMassFunction(r.Fine())
+	fine.Set(K("car"), 0.4)
+	fine.Set(K("pedestrian"), 0.5)
+	fine.Set(K("car", "truck", "bus", "pedestrian"), 0.1)
+	cf := CombineConjunctive(refined, fine)
+	assert.NotNil(cf)
+	assert.InDelta(0.4/0.65, cf.Get(K("car")), tolerance)
+	assert.InDelta(0.15/0.65, cf.Get(K("pedestrian")), tolerance)
+	assert.InDelta(0.07/0.65, cf.Get(K("car", "truck", "bus")), tolerance)
+
+	// Unbound MassFunctions only need to fit within the coarse frame
+	unbound := &MassFunction{}
+	unbound.Set(K("person"), 1.0)
+	refined, err = r.Refine(unbound)
+	assert.Nil(err)
+	assert.InDelta(1.0, refined.Get(K("pedestrian")), tolerance)
+
+	_, err = r.Refine(fine)
+	assert.NotNil(err)
+}
+
+func TestCoarsen(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	r := vehicleRefining()
+	fine := NewMassFunction(r.Fine())
+	fine.Set(K("car"), 0.5)
+	fine.Set(K("bus", "pedestrian"), 0.1)
+	fine.Set(K("car", "truck", "bus"), 0.2)
+	fine.Set(K("car", "truck", "bus", "pedestrian"), 0.2)
+
+	outer, err := r.CoarsenOuter(fine)
+	assert.Nil(err)
+	assert.True(outer.Frame() == r.Coarse())
+	assert.InDelta(0.7, outer.Get(K("vehicle")), tolerance)
+	assert.InDelta(0.3, outer.Get(K("vehicle", "person")), tolerance)
+	assert.False(outer.OpenWorld())
+	assert.True(outer.Valid())
+
+	inner, err := r.CoarsenInner(fine)
+	assert.Nil(err)
+	assert.InDelta(0.5, inner.Get(K()), tolerance)
+	assert.InDelta(0.1, inner.Get(K("person")), tolerance)
+	assert.InDelta(0.2, inner.Get(K("vehicle")), tolerance)
+	assert.InDelta(0.2, inner.Get(K("vehicle", "person")), tolerance)
+	assert.True(inner.OpenWorld())
+	assert.True(inner.Valid())
+
+	// Refining and coarsening again gets back to where we started
+	coarse := NewMassFunction(r.Coarse())
+	coarse.Set(K("vehicle"), 0.6)
+	coarse.Set(K("person"), 0.1)
+	coarse.Set(K("vehicle", "person"), 0.3)
+	refined, _ := r.Refine(coarse)
+	outer, _ = r.CoarsenOuter(refined)
+	inner, _ = r.CoarsenInner(refined)
+	for _, p := range r.Coarse().Powerset() {
+		assert.InDelta(coarse.Get(p), outer.Get(p), tolerance, p.String())
+		assert.InDelta(coarse.Get(p), inner.Get(p), tolerance, p.String())
+	}
+
+	_, err = r.CoarsenOuter(coarse)
+	assert.NotNil(err)
+}
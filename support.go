@@ -0,0 +1,131 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A SimpleSupport is a piece of evidence that supports the truth lying within
+// its focus to the degree given by its support, while saying nothing about how
+// the rest should be apportioned.
+type SimpleSupport struct {
+	Focus   functionKey
+	Support float64
+}
+
+// NewVacuousMassFunction creates a vacuous MassFunction bound to the given
+// frame, assigning all of the mass to the whole frame. It represents total
+// ignorance and is the neutral element of the conjunctive rule.
+func NewVacuousMassFunction(frame *Frame) *MassFunction {
+	mf := NewMassFunction(frame)
+	mf.setUnsafe(frame.universe(), 1.0)
+	return mf
+}
+
+// NewCategoricalMassFunction creates a categorical MassFunction bound to the
+// given frame, assigning all of the mass to the focus. Returns an error if the
+// focus is empty or isn't part of the frame.
+func NewCategoricalMassFunction(frame *Frame, focus functionKey) (*MassFunction, error) {
+	return NewSimpleMassFunction(frame, focus, 1.0)
+}
+
+// NewSimpleMassFunction creates a simple support MassFunction bound to the
+// given frame, assigning the support to the focus and the rest to the whole
+// frame. Returns an error if the focus is empty or isn't part of the frame, or
+// if the support is outside the range 0.0 >= s >= 1.0.
+func NewSimpleMassFunction(frame *Frame, focus functionKey,
+	support float64) (*MassFunction, error) {
+	bk, ok := frame.key(focus)
+	if !ok {
+		return nil, fmt.Errorf("possibility %s is not part of %s", focus, frame)
+	}
+	if bk.IsEmpty() {
+		return nil, errors.New("focus must not be empty")
+	}
+	if support < 0.0 || support > 1.0 {
+		return nil, fmt.Errorf("support out of range (%v)", support)
+	}
+	mf := NewVacuousMassFunction(frame)
+	mf.setUnsafe(frame.universe(), 1.0-support)
+	// The focus may be the whole frame, in which case it keeps all of the mass
+	mf.setUnsafe(bk, mf.value(bk)+support)
+	return mf, nil
+}
+
+// NewSeparableMassFunction creates a separable support MassFunction bound to
+// the given frame, combining a simple support MassFunction for each piece of
+// evidence according to Dempster's rule. With no evidence, the MassFunction is
+// vacuous. Conjunctive weights of the canonical decomposition, which may also
+// retract support, are handled by WeightFunction instead. Returns an error if
+// any of the evidence is invalid for NewSimpleMassFunction, or if the evidence
+// is entirely contradictory.
+func NewSeparableMassFunction(frame *Frame,
+	supports ...SimpleSupport) (*MassFunction, error) {
+	simple := make([]*MassFunction, len(supports))
+	for i, s := range supports {
+		smf, err := NewSimpleMassFunction(frame, s.Focus, s.Support)
+		if err != nil {
+			return nil, err
+		}
+		simple[i] = smf
+	}
+	masses := conjunctiveMasses(frame, simple...)
+	conflict := masses[bitKey{}]
+	if floatFixed(conflict, 5) >= 1.0 {
+		return nil, errors.New("evidence is entirely contradictory")
+	}
+	mf := NewMassFunction(frame)
+	for p, v := range masses {
+		if !p.IsEmpty() {
+			mf.setUnsafe(p, v/(1.0-conflict))
+		}
+	}
+	return mf, nil
+}
+
+// IsVacuous returns true if the MassFunction assigns all of its mass to the
+// whole frame.
+func (mf *MassFunction) IsVacuous() bool {
+	frame, _ := mf.frameInfo()
+	fs := mf.focalSets(frame)
+	return len(fs) == 1 && fs[0].key == frame.universe()
+}
+
+// IsCategorical returns true if the MassFunction assigns all of its mass to a
+// single non-empty possibility. A vacuous MassFunction is also categorical.
+func (mf *MassFunction) IsCategorical() bool {
+	frame, _ := mf.frameInfo()
+	fs := mf.focalSets(frame)
+	return len(fs) == 1 && !fs[0].key.IsEmpty()
+}
+
+// IsSimple returns true if the MassFunction is a simple support function,
+// assigning mass to at most one non-empty possibility besides the whole frame.
+// Vacuous and categorical MassFunctions are also simple.
+func (mf *MassFunction) IsSimple() bool {
+	frame, _ := mf.frameInfo()
+	universe := frame.universe()
+	others := 0
+	for _, f := range mf.focalSets(frame) {
+		if f.key.IsEmpty() {
+			return false
+		}
+		if f.key != universe {
+			others++
+		}
+	}
+	return others <= 1
+}
+
+// IsDogmatic returns true if the MassFunction assigns no mass to the whole
+// frame, leaving no room for doubt about its evidence.
+func (mf *MassFunction) IsDogmatic() bool {
+	frame, _ := mf.frameInfo()
+	universe := frame.universe()
+	for _, f := range mf.focalSets(frame) {
+		if f.key == universe {
+			return false
+		}
+	}
+	return true
+}
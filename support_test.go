@@ -0,0 +1,129 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSimpleMassFunction(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	frame, _ := NewFrame("a", "b", "c")
+	mf, err := NewSimpleMassFunction(frame, K("a", "b"), 0.7)
+	assert.Nil(err)
+	assert.True(mf.Frame() == frame)
+	assert.InDelta(0.7, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.3, mf.Get(K("a", "b", "c")), tolerance)
+	assert.True(mf.Valid())
+
+	// Supporting the whole frame tells us nothing
+	mf, err = NewSimpleMassFunction(frame, K("a", "b", "c"), 0.7)
+	assert.Nil(err)
+	assert.InDelta(1.0, mf.Get(K("a", "b", "c")), tolerance)
+
+	_, err = NewSimpleMassFunction(frame, K("d"), 0.7)
+	assert.NotNil(err)
+	_, err = NewSimpleMassFunction(frame, K(), 0.7)
+	assert.NotNil(err)
+	_, err = NewSimpleMassFunction(frame, K("a"), 1.1)
+	assert.NotNil(err)
+
+	mf, err = NewCategoricalMassFunction(frame, K("b"))
+	assert.Nil(err)
+	assert.InDelta(1.0, mf.Get(K("b")), tolerance)
+	assert.InDelta(0.0, mf.Get(K("a", "b", "c")), tolerance)
+	assert.True(mf.Valid())
+
+	mf = NewVacuousMassFunction(frame)
+	assert.True(mf.Frame() == frame)
+	assert.InDelta(1.0, mf.Get(K("a", "b", "c")), tolerance)
+	assert.True(mf.Valid())
+}
+
+func TestNewSeparableMassFunction(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	frame, _ := NewFrame("a", "b", "c")
+	mf, err := NewSeparableMassFunction(frame,
+		SimpleSupport{Focus: K("a", "b"), Support: 0.6},
+		SimpleSupport{Focus: K("b", "c"), Support: 0.5},
+	)
+	assert.Nil(err)
+	assert.True(mf.Frame() == frame)
+	assert.InDelta(0.3, mf.Get(K("b")), tolerance)
+	assert.InDelta(0.3, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.2, mf.Get(K("b", "c")), tolerance)
+	assert.InDelta(0.2, mf.Get(K("a", "b", "c")), tolerance)
+	assert.True(mf.Valid())
+
+	// Conflicting evidence is normalized away
+	mf, err = NewSeparableMassFunction(frame,
+		SimpleSupport{Focus: K("a"), Support: 0.6},
+		SimpleSupport{Focus: K("b"), Support: 0.5},
+	)
+	assert.Nil(err)
+	assert.InDelta(0.42857, mf.Get(K("a")), tolerance)
+	assert.InDelta(0.28571, mf.Get(K("b")), tolerance)
+	assert.InDelta(0.28571, mf.Get(K("a", "b", "c")), tolerance)
+	assert.InDelta(0.0, mf.Get(K()), tolerance)
+	assert.True(mf.Valid())
+
+	mf, err = NewSeparableMassFunction(frame)
+	assert.Nil(err)
+	assert.True(mf.IsVacuous())
+
+	_, err = NewSeparableMassFunction(frame,
+		SimpleSupport{Focus: K("a"), Support: 1.0},
+		SimpleSupport{Focus: K("b"), Support: 1.0},
+	)
+	assert.NotNil(err)
+	_, err = NewSeparableMassFunction(frame,
+		SimpleSupport{Focus: K("d"), Support: 0.5},
+	)
+	assert.NotNil(err)
+}
+
+func TestSupportPredicates(t *testing.T) {
+	frame, _ := NewFrame("a", "b", "c")
+	vacuous := NewVacuousMassFunction(frame)
+	categorical, _ := NewCategoricalMassFunction(frame, K("a", "b"))
+	simple, _ := NewSimpleMassFunction(frame, K("a"), 0.4)
+	separable, _ := NewSeparableMassFunction(frame,
+		SimpleSupport{Focus: K("a", "b"), Support: 0.6},
+		SimpleSupport{Focus: K("b", "c"), Support: 0.5},
+	)
+	conflicting := &MassFunction{}
+	conflicting.SetOpenWorld(true)
+	conflicting.Set(K(), 0.5)
+	conflicting.Set(K("a"), 0.5)
+
+	tcs := []struct {
+		name        string
+		mf          *MassFunction
+		vacuous     bool
+		categorical bool
+		simple      bool
+		dogmatic    bool
+	}{
+		{"vacuous", vacuous, true, true, true, false},
+		{"categorical", categorical, false, true, true, true},
+		{"simple", simple, false, false, true, false},
+		{"separable", separable, false, false, false, false},
+		{"bayesian", trafficLight().Pignistic(), false, false, false, true},
+		{"traffic light", trafficLight(), false, false, false, false},
+		{"conflicting", conflicting, false, false, false, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.vacuous, tc.mf.IsVacuous())
+			assert.Equal(tc.categorical, tc.mf.IsCategorical())
+			assert.Equal(tc.simple, tc.mf.IsSimple())
+			assert.Equal(tc.dogmatic, tc.mf.IsDogmatic())
+		})
+	}
+}
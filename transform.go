@@ -0,0 +1,186 @@
+package evidence
+
+import (
+	"errors"
+	"math"
+)
+
+// Besides the pignistic transformation, several other transformations turn a
+// MassFunction into a probability distribution over its hypotheses, as a
+// MassFunction with only singleton focal sets. Most of them share out the mass
+// of each focal set among its hypotheses in proportion to some weight of each
+// hypothesis, differing only in the weights they use. Mass assigned to the
+// empty set is ignored, as it is by Pignistic.
+
+// singletonMasses returns the mass assigned to each singleton, by position.
+func singletonMasses(fs []focal) map[int]float64 {
+	masses := make(map[int]float64)
+	for _, f := range fs {
+		if f.key.Len() == 1 {
+			masses[f.key.Elements()[0]] += f.value
+		}
+	}
+	return masses
+}
+
+// singletonPlausibilities returns the plausibility of each hypothesis, by
+// position.
+func singletonPlausibilities(fs []focal) map[int]float64 {
+	pl := make(map[int]float64)
+	for _, f := range fs {
+		for _, i := range f.key.Elements() {
+			pl[i] += f.value
+		}
+	}
+	return pl
+}
+
+// proportional shares out the mass of each focal set among its hypotheses in
+// proportion to the weight of each hypothesis. If none of the hypotheses in a
+// focal set have any weight, its mass is shared out evenly instead.
+func proportional(fs []focal, weight func(int) float64) map[int]float64 {
+	probabilities := make(map[int]float64)
+	for _, f := range fs {
+		elements := f.key.Elements()
+		total := 0.0
+		for _, i := range elements {
+			total += weight(i)
+		}
+		for _, i := range elements {
+			if total > 0.0 {
+				probabilities[i] += f.value * weight(i) / total
+			} else {
+				probabilities[i] += f.value / float64(len(elements))
+			}
+		}
+	}
+	return probabilities
+}
+
+// transform applies a transformation to the MassFunction's non-empty focal
+// sets and returns the resulting probabilities as a new MassFunction.
+func (mf *MassFunction) transform(
+	transformation func(fs []focal) map[int]float64) *MassFunction {
+	frame, bound := mf.frameInfo()
+	nmf := &MassFunction{}
+	nmf.bind(frame, bound)
+	for i, p := range transformation(mf.nonEmptyFocalSets()) {
+		nmf.setUnsafe(singletonBitKey(i), p)
+	}
+	return nmf
+}
+
+// normalized scales a set of weights so that they sum to 1.0.
+func normalized(weights map[int]float64) map[int]float64 {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
+}
+
+// PlausibilityTransform returns a new MassFunction after application of Cobb
+// and Shenoy's plausibility transformation, in which the probability of each
+// hypothesis is proportional to its plausibility. Unlike the pignistic
+// transformation, it is consistent with Dempster's rule: the transform of a
+// combination is the combination of the transforms.
+func (mf *MassFunction) PlausibilityTransform() *MassFunction {
+	return mf.transform(func(fs []focal) map[int]float64 {
+		return normalized(singletonPlausibilities(fs))
+	})
+}
+
+// BeliefTransform returns a new MassFunction after application of the
+// normalized belief transformation, in which the probability of each hypothesis
+// is proportional to its belief, i.e. to the mass assigned to it alone.
+// Returns an error if no mass is assigned to any singleton.
+func (mf *MassFunction) BeliefTransform() (*MassFunction, error) {
+	if len(singletonMasses(mf.nonEmptyFocalSets())) == 0 {
+		return nil, errors.New("no belief in any single hypothesis")
+	}
+	return mf.transform(func(fs []focal) map[int]float64 {
+		return normalized(singletonMasses(fs))
+	}), nil
+}
+
+// PrPl returns a new MassFunction after application of Sudano's PrPl
+// transformation, which shares out the mass of each focal set among its
+// hypotheses in proportion to their plausibilities.
+func (mf *MassFunction) PrPl() *MassFunction {
+	return mf.transform(func(fs []focal) map[int]float64 {
+		pl := singletonPlausibilities(fs)
+		return proportional(fs, func(i int) float64 { return pl[i] })
+	})
+}
+
+// PrBel returns a new MassFunction after application of Sudano's PrBel
+// transformation, which shares out the mass of each focal set among its
+// hypotheses in proportion to their beliefs. The mass of focal sets that don't
+// contain any believed hypothesis is shared out evenly.
+func (mf *MassFunction) PrBel() *MassFunction {
+	return mf.transform(func(fs []focal) map[int]float64 {
+		bel := singletonMasses(fs)
+		return proportional(fs, func(i int) float64 { return bel[i] })
+	})
+}
+
+// PrHyb returns a new MassFunction after application of Sudano's hybrid PrHyb
+// transformation, which shares out the mass of each focal set among its
+// hypotheses in proportion to PraPl, a probability that adds a share of the
+// unassigned mass to each hypothesis's belief in proportion to its
+// plausibility: PraPl(x) = Bel(x) + ε·Pl(x), where ε = (1-ΣBel)/ΣPl.
+func (mf *MassFunction) PrHyb() *MassFunction {
+	return mf.transform(func(fs []focal) map[int]float64 {
+		bel := singletonMasses(fs)
+		pl := singletonPlausibilities(fs)
+		totalBel, totalPl := 0.0, 0.0
+		for _, v := range bel {
+			totalBel += v
+		}
+		for _, v := range pl {
+			totalPl += v
+		}
+		epsilon := (1.0 - totalBel) / totalPl
+		return proportional(fs, func(i int) float64 {
+			return bel[i] + epsilon*pl[i]
+		})
+	})
+}
+
+// DSmP returns a new MassFunction after application of Dezert and
+// Smarandache's DSmP transformation, which shares out the mass of each focal
+// set among its hypotheses in proportion to their beliefs plus epsilon. A small
+// positive epsilon keeps focal sets with no believed hypotheses from being
+// ignored, while larger values move the result towards the pignistic
+// transformation. With an epsilon of 0.0, the mass of such focal sets is shared
+// out evenly. Returns an error if epsilon is negative.
+func (mf *MassFunction) DSmP(epsilon float64) (*MassFunction, error) {
+	if epsilon < 0.0 {
+		return nil, errors.New("epsilon must not be negative")
+	}
+	return mf.transform(func(fs []focal) map[int]float64 {
+		bel := singletonMasses(fs)
+		return proportional(fs, func(i int) float64 { return bel[i] + epsilon })
+	}), nil
+}
+
+// PIC returns the probabilistic information content of a probability
+// distribution over the MassFunction's frame, as produced by one of its
+// transformations: 1 + Σ p·log2(p) / log2(n), where n is the number of
+// hypotheses. It is 0.0 for the uniform distribution and 1.0 when all of the
+// probability is assigned to a single hypothesis. Only mass assigned to
+// singletons is considered.
+func (mf *MassFunction) PIC() float64 {
+	n := mf.Frame().Len()
+	if n < 2 {
+		return 1.0
+	}
+	negentropy := 0.0
+	for _, p := range singletonMasses(mf.nonEmptyFocalSets()) {
+		negentropy += p * math.Log2(p)
+	}
+	return 1.0 + negentropy/math.Log2(float64(n))
+}
@@ -0,0 +1,139 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransforms(t *testing.T) {
+	const tolerance = 0.0001
+
+	beliefTransform := func(mf *MassFunction) *MassFunction {
+		tmf, _ := mf.BeliefTransform()
+		return tmf
+	}
+	dsmp := func(mf *MassFunction) *MassFunction {
+		tmf, _ := mf.DSmP(0.001)
+		return tmf
+	}
+
+	tcs := []struct {
+		name      string
+		transform func(*MassFunction) *MassFunction
+		expected  []float64
+		pic       float64
+	}{
+		{
+			name:      "pignistic",
+			transform: (*MassFunction).Pignistic,
+			expected:  []float64{0.43833, 0.33333, 0.22833},
+			pic:       0.03063,
+		},
+		{
+			name:      "plausibility",
+			transform: (*MassFunction).PlausibilityTransform,
+			expected:  []float64{0.41481, 0.33333, 0.25185},
+			pic:       0.01831,
+		},
+		{
+			name:      "belief",
+			transform: beliefTransform,
+			expected:  []float64{0.46667, 0.33333, 0.2},
+			pic:       0.04993,
+		},
+		{
+			name:      "PrPl",
+			transform: (*MassFunction).PrPl,
+			expected:  []float64{0.45586, 0.33285, 0.21129},
+			pic:       0.04177,
+		},
+		{
+			name:      "PrBel",
+			transform: (*MassFunction).PrBel,
+			expected:  []float64{0.46667, 0.33333, 0.2},
+			pic:       0.04993,
+		},
+		{
+			name:      "PrHyb",
+			transform: (*MassFunction).PrHyb,
+			expected:  []float64{0.46399, 0.33315, 0.20286},
+			pic:       0.04781,
+		},
+		{
+			name:      "DSmP",
+			transform: dsmp,
+			expected:  []float64{0.46656, 0.33333, 0.20012},
+			pic:       0.04984,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mf := trafficLight()
+			tmf := tc.transform(mf)
+			assert.True(tmf.Frame() == mf.Frame())
+			assert.InDelta(tc.expected[0], tmf.Get(K("red")), tolerance)
+			assert.InDelta(tc.expected[1], tmf.Get(K("yellow")), tolerance)
+			assert.InDelta(tc.expected[2], tmf.Get(K("green")), tolerance)
+			assert.InDelta(0.0, tmf.Get(K("red", "yellow")), tolerance)
+			assert.InDelta(0.0, tmf.Get(K("red", "yellow", "green")), tolerance)
+			assert.True(tmf.Valid())
+			assert.InDelta(tc.pic, tmf.PIC(), tolerance)
+		})
+	}
+}
+
+func TestTransformsWithoutSingletons(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.0001
+
+	mf := &MassFunction{}
+	mf.Set(K("a", "b"), 0.6)
+	mf.Set(K("b", "c"), 0.4)
+
+	_, err := mf.BeliefTransform()
+	assert.NotNil(err)
+
+	// With no belief in any hypothesis, PrBel and DSmP fall back to sharing
+	// out each focal set evenly, while PrHyb reduces to PrPl
+	for _, tmf := range []*MassFunction{mf.PrBel(), mustDSmP(mf, 0.0), mustDSmP(mf, 0.001)} {
+		assert.InDelta(0.3, tmf.Get(K("a")), tolerance)
+		assert.InDelta(0.5, tmf.Get(K("b")), tolerance)
+		assert.InDelta(0.2, tmf.Get(K("c")), tolerance)
+	}
+	for _, tmf := range []*MassFunction{mf.PrPl(), mf.PrHyb()} {
+		assert.InDelta(0.225, tmf.Get(K("a")), tolerance)
+		assert.InDelta(0.66071, tmf.Get(K("b")), tolerance)
+		assert.InDelta(0.11429, tmf.Get(K("c")), tolerance)
+	}
+
+	_, err = mf.DSmP(-0.1)
+	assert.NotNil(err)
+}
+
+func mustDSmP(mf *MassFunction, epsilon float64) *MassFunction {
+	tmf, err := mf.DSmP(epsilon)
+	if err != nil {
+		panic(err)
+	}
+	return tmf
+}
+
+func TestPIC(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	uniform := &MassFunction{}
+	uniform.Set(K("a"), 0.25)
+	uniform.Set(K("b"), 0.25)
+	uniform.Set(K("c"), 0.25)
+	uniform.Set(K("d"), 0.25)
+	assert.InDelta(0.0, uniform.PIC(), tolerance)
+
+	certain := &MassFunction{}
+	certain.Set(K("a"), 1.0)
+	certain.Set(K("b"), 0.0)
+	assert.InDelta(1.0, certain.PIC(), tolerance)
+}
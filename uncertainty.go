@@ -0,0 +1,161 @@
+package evidence
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Measures of the uncertainty in a MassFunction, all in bits. Mass assigned to
+// the empty set carries no information about the frame and is ignored by each
+// of them, as it is by Entropy.
+
+// nonEmptyFocalSets returns the MassFunction's focal sets other than the empty
+// set, over its own frame.
+func (mf *MassFunction) nonEmptyFocalSets() (fs []focal) {
+	frame, _ := mf.frameInfo()
+	for _, f := range mf.focalSets(frame) {
+		if !f.key.IsEmpty() {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+// Nonspecificity returns Dubois and Prade's nonspecificity of the
+// MassFunction, Σ m(A)·log2|A|, the generalization of the Hartley measure to
+// mass functions. It measures the imprecision of the evidence, and is 0.0 when
+// every focal set is a singleton.
+func (mf *MassFunction) Nonspecificity() (n float64) {
+	for _, f := range mf.nonEmptyFocalSets() {
+		n += f.value * math.Log2(float64(f.key.Len()))
+	}
+	return n
+}
+
+// Dissonance returns Yager's dissonance of the MassFunction,
+// -Σ m(A)·log2 Pl(A), which measures how much of the mass supports
+// possibilities that other mass contradicts.
+func (mf *MassFunction) Dissonance() (e float64) {
+	fs := mf.nonEmptyFocalSets()
+	for _, f1 := range fs {
+		pl := 0.0
+		for _, f2 := range fs {
+			if f1.key.Intersects(f2.key) {
+				pl += f2.value
+			}
+		}
+		e -= f1.value * math.Log2(pl)
+	}
+	return e
+}
+
+// Discord returns Klir and Ramer's discord of the MassFunction,
+// -Σ m(A)·log2(1 - Σ m(B)·|B\A|/|B|), which measures the conflict between
+// focal sets, discounting conflict with focal sets that only partially
+// disagree with each other.
+func (mf *MassFunction) Discord() (d float64) {
+	fs := mf.nonEmptyFocalSets()
+	for _, a := range fs {
+		conflict := 0.0
+		for _, b := range fs {
+			conflict += b.value * float64(b.key.Difference(a.key).Len()) /
+				float64(b.key.Len())
+		}
+		d -= a.value * math.Log2(1.0-conflict)
+	}
+	return d
+}
+
+// Strife returns Klir and Parviz's strife of the MassFunction,
+// -Σ m(A)·log2(1 - Σ m(B)·|A\B|/|A|). Unlike Discord, the conflict of a focal
+// set with a more specific focal set that it contains isn't discounted.
+func (mf *MassFunction) Strife() (s float64) {
+	fs := mf.nonEmptyFocalSets()
+	for _, a := range fs {
+		conflict := 0.0
+		for _, b := range fs {
+			conflict += b.value * float64(a.key.Difference(b.key).Len()) /
+				float64(a.key.Len())
+		}
+		s -= a.value * math.Log2(1.0-conflict)
+	}
+	return s
+}
+
+// AggregateUncertainty returns Harmanec and Klir's aggregate uncertainty of the
+// MassFunction, the largest Shannon entropy of any probability distribution
+// that is consistent with its beliefs. The distribution is found with the
+// iterative algorithm of Meyerowitz, Richman and Walker: the possibility A with
+// the largest Bel(A)/|A| has its belief spread evenly across its hypotheses,
+// after which it is removed from the frame and the remaining beliefs are
+// conditioned on its removal, until no belief remains. Returns NaN if the frame
+// is too large to enumerate.
+func (mf *MassFunction) AggregateUncertainty() (au float64) {
+	bel, _, _, err := mf.dense()
+	if err != nil {
+		return math.NaN()
+	}
+	bel[0] = 0.0
+	zetaSubsets(bel)
+	// Probabilities that differ by less than this are considered equal
+	const epsilon = 1e-12
+	remaining := len(bel) - 1
+	for remaining != 0 && bel[remaining] > epsilon {
+		// Ties go to the larger possibility, so that every hypothesis in it
+		// shares the same probability
+		best, bestRatio := 0, 0.0
+		for a := remaining; a != 0; a = (a - 1) & remaining {
+			size := bits.OnesCount(uint(a))
+			ratio := bel[a] / float64(size)
+			if ratio > bestRatio+epsilon || (ratio > bestRatio-epsilon &&
+				size > bits.OnesCount(uint(best))) {
+				best, bestRatio = a, ratio
+			}
+		}
+		au -= bel[best] * math.Log2(bestRatio)
+		belBest := bel[best]
+		remaining &^= best
+		for b := remaining; ; b = (b - 1) & remaining {
+			bel[b] = bel[b|best] - belBest
+			if b == 0 {
+				break
+			}
+		}
+	}
+	return au
+}
+
+// Ambiguity returns Jousselme's ambiguity measure of the MassFunction, the
+// Shannon entropy of its pignistic probabilities.
+func (mf *MassFunction) Ambiguity() (am float64) {
+	betP := make(map[int]float64)
+	for _, f := range mf.nonEmptyFocalSets() {
+		elements := f.key.Elements()
+		for _, i := range elements {
+			betP[i] += f.value / float64(len(elements))
+		}
+	}
+	for _, p := range betP {
+		if p > 0.0 {
+			am -= p * math.Log2(p)
+		}
+	}
+	return am
+}
+
+// JirousekShenoyEntropy returns Jiroušek and Shenoy's entropy of the
+// MassFunction, the Shannon entropy of its plausibility transform plus its
+// Nonspecificity. The first term measures conflict and the second
+// imprecision.
+func (mf *MassFunction) JirousekShenoyEntropy() float64 {
+	pl := singletonPlausibilities(mf.nonEmptyFocalSets())
+	total := 0.0
+	for _, v := range pl {
+		total += v
+	}
+	h := 0.0
+	for _, v := range pl {
+		h -= v / total * math.Log2(v/total)
+	}
+	return h + mf.Nonspecificity()
+}
@@ -0,0 +1,110 @@
+package evidence
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUncertaintyMeasures(t *testing.T) {
+	const tolerance = 0.0001
+
+	nested := &MassFunction{}
+	nested.Set(K("a"), 0.5)
+	nested.Set(K("a", "b"), 0.3)
+	nested.Set(K("c", "d"), 0.2)
+
+	simple := &MassFunction{}
+	simple.Set(K("a"), 0.6)
+	simple.Set(K("a", "b", "c", "d"), 0.4)
+
+	tcs := []struct {
+		name     string
+		measure  func(*MassFunction) float64
+		expected []float64
+	}{
+		{
+			name:     "nonspecificity",
+			measure:  (*MassFunction).Nonspecificity,
+			expected: []float64{0.30850, 0.5, 0.8},
+		},
+		{
+			name:     "dissonance",
+			measure:  (*MassFunction).Dissonance,
+			expected: []float64{0.87391, 0.72193, 0.0},
+		},
+		{
+			name:     "discord",
+			measure:  (*MassFunction).Discord,
+			expected: []float64{1.21730, 0.87171, 0.30874},
+		},
+		{
+			name:     "strife",
+			measure:  (*MassFunction).Strife,
+			expected: []float64{1.09978, 0.88410, 0.34500},
+		},
+		{
+			name:     "aggregate uncertainty",
+			measure:  (*MassFunction).AggregateUncertainty,
+			expected: []float64{1.58407, 1.68548, 1.60494},
+		},
+		{
+			name:     "ambiguity",
+			measure:  (*MassFunction).Ambiguity,
+			expected: []float64{1.53642, 1.47890, 1.35678},
+		},
+		{
+			name:     "Jiroušek-Shenoy entropy",
+			measure:  (*MassFunction).JirousekShenoyEntropy,
+			expected: []float64{1.86443, 2.22323, 2.65856},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			for i, mf := range []*MassFunction{trafficLight(), nested, simple} {
+				assert.InDelta(tc.expected[i], tc.measure(mf), tolerance, mf.String())
+			}
+		})
+	}
+}
+
+func TestUncertaintyMeasuresBayesian(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// For a probability distribution, the measures of conflict all reduce to
+	// Shannon entropy and there is no nonspecificity
+	mf := &MassFunction{}
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("b"), 0.25)
+	mf.Set(K("c"), 0.25)
+	assert.InDelta(0.0, mf.Nonspecificity(), tolerance)
+	assert.InDelta(1.5, mf.Dissonance(), tolerance)
+	assert.InDelta(1.5, mf.Discord(), tolerance)
+	assert.InDelta(1.5, mf.Strife(), tolerance)
+	assert.InDelta(1.5, mf.AggregateUncertainty(), tolerance)
+	assert.InDelta(1.5, mf.Ambiguity(), tolerance)
+	assert.InDelta(1.5, mf.JirousekShenoyEntropy(), tolerance)
+	assert.InDelta(1.5, mf.Entropy(), tolerance)
+
+	// Total ignorance is maximally nonspecific and has no conflict
+	vacuous := &MassFunction{}
+	vacuous.Set(K("a", "b", "c", "d"), 1.0)
+	assert.InDelta(2.0, vacuous.Nonspecificity(), tolerance)
+	assert.InDelta(0.0, vacuous.Discord(), tolerance)
+	assert.InDelta(2.0, vacuous.AggregateUncertainty(), tolerance)
+	assert.InDelta(2.0, vacuous.Ambiguity(), tolerance)
+}
+
+func TestAggregateUncertaintyLargeFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	frame, _ := largeFrame()
+	mf := NewMassFunction(frame)
+	mf.Set(K("h0"), 1.0)
+	assert.True(math.IsNaN(mf.AggregateUncertainty()))
+	assert.InDelta(0.0, mf.Nonspecificity(), 0.00001)
+}
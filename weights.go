@@ -0,0 +1,365 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// A WeightFunction is a mapping of possibilities to the weights of Denœux's
+// canonical decomposition of a MassFunction. Conjunctive weights w(A), defined
+// for every possibility other than the whole frame, describe a nondogmatic
+// MassFunction as the conjunctive combination of simple mass functions
+// assigning 1-w(A) to A and w(A) to the frame. Disjunctive weights v(A),
+// defined for every possibility other than the empty set, describe a
+// subnormal MassFunction as the disjunctive combination of mass functions
+// assigning 1-v(A) to A and v(A) to the empty set. Weights greater than 1.0
+// are permitted and stand for the retraction of support rather than support.
+type WeightFunction struct {
+	Function
+	disjunctive bool
+}
+
+// NewWeightFunction creates an empty WeightFunction bound to the given frame,
+// holding disjunctive weights if disjunctive is true and conjunctive weights
+// otherwise.
+func NewWeightFunction(frame *Frame, disjunctive bool) *WeightFunction {
+	wf := &WeightFunction{disjunctive: disjunctive}
+	wf.bind(frame, true)
+	return wf
+}
+
+// Disjunctive returns true if the WeightFunction holds disjunctive rather than
+// conjunctive weights.
+func (wf *WeightFunction) Disjunctive() bool {
+	return wf.disjunctive
+}
+
+// Set assigns a weight to a given possibility. Weights must be positive but,
+// unlike probabilities, may exceed 1.0.
+func (wf *WeightFunction) Set(key functionKey, weight float64) (err error) {
+	wf.mux.Lock()
+	defer wf.mux.Unlock()
+	wf.init()
+	if weight <= 0.0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		return errors.New("weight out of range")
+	}
+	bk, ok := wf.frame.key(key)
+	if !ok && wf.bound {
+		return fmt.Errorf("possibility %s is not part of %s", key, wf.frame)
+	}
+	if !ok {
+		focals := key.FocalElements()
+		hypotheses := make([]string, 0, len(focals))
+		for _, focus := range focals {
+			hypotheses = append(hypotheses, string(focus))
+		}
+		wf.frame = wf.frame.extend(hypotheses...)
+		bk, _ = wf.frame.key(key)
+	}
+	wf.possibilities[bk] = weight
+	return nil
+}
+
+// Get returns the weight of a given possibility. Possibilities without an
+// assigned weight have the neutral weight of 1.0.
+func (wf *WeightFunction) Get(key functionKey) (weight float64) {
+	wf.mux.Lock()
+	wf.init()
+	weight = 1.0
+	if bk, ok := wf.frame.key(key); ok {
+		if w, ok := wf.possibilities[bk]; ok {
+			weight = w
+		}
+	}
+	wf.mux.Unlock()
+	return
+}
+
+// Valid verifies that a given WeightFunction meets the defined requirements for
+// one. All weights must be positive and finite.
+func (wf *WeightFunction) Valid() bool {
+	wf.mux.Lock()
+	wf.init()
+	for _, weight := range wf.possibilities {
+		if weight <= 0.0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			wf.mux.Unlock()
+			return false
+		}
+	}
+	wf.mux.Unlock()
+	return true
+}
+
+// String presents a human-readable version of the weights that differ from
+// the neutral weight of 1.0, one simple mass function per line.
+func (wf *WeightFunction) String() string {
+	var sb strings.Builder
+	for _, p := range wf.Possibilities() {
+		if w := wf.Get(p); w != 1.0 {
+			sb.WriteString(fmt.Sprintf("%s\t%f\n", p, w))
+		}
+	}
+	return sb.String()
+}
+
+// Separable returns true if every weight is at most 1.0, meaning that the
+// MassFunction is the combination of simple support functions only.
+func (wf *WeightFunction) Separable() bool {
+	wf.mux.Lock()
+	wf.init()
+	for _, weight := range wf.possibilities {
+		if weight > 1.0 {
+			wf.mux.Unlock()
+			return false
+		}
+	}
+	wf.mux.Unlock()
+	return true
+}
+
+var (
+	errDogmatic    = errors.New("dogmatic mass functions have no conjunctive weights")
+	errNormal      = errors.New("normal mass functions have no disjunctive weights")
+	errNotPositive = errors.New(
+		"functions with values that aren't positive have no weights")
+)
+
+// positive returns true if every value in a dense vector is positive, as is
+// every commonality of a nondogmatic MassFunction and every belief of a
+// subnormal one.
+func positive(v []float64) bool {
+	for _, x := range v {
+		if x <= 0.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// conjunctiveWeights computes the conjunctive weights for a dense vector of
+// masses from their commonality function.
+func conjunctiveWeights(masses []float64) ([]float64, error) {
+	if masses[len(masses)-1] <= 0.0 {
+		return nil, errDogmatic
+	}
+	q := append([]float64{}, masses...)
+	zetaSupersets(q)
+	return commonalityWeights(q), nil
+}
+
+// commonalityWeights converts a dense commonality function into conjunctive
+// weights in place. Since ln w(A) = -Σ_{B⊇A} (-1)^{|B|-|A|} ln q(B), this is a
+// Möbius transform of the logarithm of the commonality function. The entry for
+// the whole frame is left at the neutral weight.
+func commonalityWeights(v []float64) []float64 {
+	for i := range v {
+		v[i] = math.Log(v[i])
+	}
+	mobiusSupersets(v)
+	for i := range v {
+		v[i] = math.Exp(-v[i])
+	}
+	v[len(v)-1] = 1.0
+	return v
+}
+
+// massesFromConjunctiveWeights is the inverse of conjunctiveWeights.
+func massesFromConjunctiveWeights(weights []float64) []float64 {
+	universe := len(weights) - 1
+	v := make([]float64, len(weights))
+	// The commonality of the whole frame is its mass, which is the product of
+	// every weight.
+	for i := 0; i < universe; i++ {
+		v[i] = -math.Log(weights[i])
+		v[universe] -= v[i]
+	}
+	zetaSupersets(v)
+	for i := range v {
+		v[i] = math.Exp(v[i])
+	}
+	mobiusSupersets(v)
+	return v
+}
+
+// disjunctiveWeights computes the disjunctive weights for a dense vector of
+// masses from their implicability function.
+func disjunctiveWeights(masses []float64) ([]float64, error) {
+	if masses[0] <= 0.0 {
+		return nil, errNormal
+	}
+	b := append([]float64{}, masses...)
+	zetaSubsets(b)
+	return implicabilityWeights(b), nil
+}
+
+// implicabilityWeights converts a dense implicability function into
+// disjunctive weights in place. Since ln v(A) = -Σ_{B⊆A} (-1)^{|A|-|B|} ln b(B),
+// this is a Möbius transform of the logarithm of the implicability function.
+// The entry for the empty set is left at the neutral weight.
+func implicabilityWeights(v []float64) []float64 {
+	for i := range v {
+		v[i] = math.Log(v[i])
+	}
+	mobiusSubsets(v)
+	for i := range v {
+		v[i] = math.Exp(-v[i])
+	}
+	v[0] = 1.0
+	return v
+}
+
+// massesFromDisjunctiveWeights is the inverse of disjunctiveWeights.
+func massesFromDisjunctiveWeights(weights []float64) []float64 {
+	v := make([]float64, len(weights))
+	// The implicability of the empty set is its mass, which is the product of
+	// every weight.
+	for i := 1; i < len(weights); i++ {
+		v[i] = -math.Log(weights[i])
+		v[0] -= v[i]
+	}
+	zetaSubsets(v)
+	for i := range v {
+		v[i] = math.Exp(v[i])
+	}
+	mobiusSubsets(v)
+	return v
+}
+
+// newWeightFunction wraps a dense vector of weights over a frame, skipping the
+// entry that has no weight.
+func newWeightFunction(frame *Frame, bound bool, weights []float64,
+	disjunctive bool) (wf *WeightFunction) {
+	wf = &WeightFunction{disjunctive: disjunctive}
+	wf.bind(frame, bound)
+	skip := len(weights) - 1
+	if disjunctive {
+		skip = 0
+	}
+	for i, w := range weights {
+		if i != skip {
+			// Weights aren't truncated, since small differences matter once
+			// they're multiplied together.
+			wf.possibilities[bitKey{lo: uint64(i)}] = w
+		}
+	}
+	return wf
+}
+
+// ConjunctiveWeights returns the conjunctive weights of the MassFunction's
+// canonical decomposition. This is equivalent to mf.Commonality().Weights(),
+// without truncating the intermediate commonalities. Returns an error if the
+// MassFunction is dogmatic, i.e. assigns no mass to the whole frame, or if the
+// frame is too large to enumerate.
+func (mf *MassFunction) ConjunctiveWeights() (*WeightFunction, error) {
+	v, frame, bound, err := mf.dense()
+	if err != nil {
+		return nil, err
+	}
+	weights, err := conjunctiveWeights(v)
+	if err != nil {
+		return nil, err
+	}
+	return newWeightFunction(frame, bound, weights, false), nil
+}
+
+// DisjunctiveWeights returns the disjunctive weights of the MassFunction's
+// canonical decomposition. This is equivalent to mf.Belief().Weights(),
+// without truncating the intermediate beliefs. Returns an error if the
+// MassFunction is normal, i.e. assigns no mass to the empty set, or if the
+// frame is too large to enumerate.
+func (mf *MassFunction) DisjunctiveWeights() (*WeightFunction, error) {
+	v, frame, bound, err := mf.dense()
+	if err != nil {
+		return nil, err
+	}
+	weights, err := disjunctiveWeights(v)
+	if err != nil {
+		return nil, err
+	}
+	return newWeightFunction(frame, bound, weights, true), nil
+}
+
+// dense returns the weights as a vector indexed by the bitmask of each
+// possibility, with the neutral weight of 1.0 wherever none is assigned, along
+// with the frame they're indexed over. Returns an error if the frame is too
+// large to enumerate.
+func (wf *WeightFunction) dense() (weights []float64, frame *Frame, bound bool,
+	err error) {
+	wf.mux.Lock()
+	defer wf.mux.Unlock()
+	wf.init()
+	size, err := wf.frame.powersetSize()
+	if err != nil {
+		return nil, wf.frame, wf.bound, err
+	}
+	weights = make([]float64, size)
+	for i := range weights {
+		weights[i] = 1.0
+	}
+	for bk, w := range wf.possibilities {
+		weights[bk.lo] = w
+	}
+	return weights, wf.frame, wf.bound, nil
+}
+
+// Mass reconstructs the MassFunction described by the weights by combining the
+// simple mass functions they stand for. Any weight assigned to the whole frame,
+// or to the empty set for disjunctive weights, is ignored. The MassFunction
+// follows the open-world assumption if it assigns mass to the empty set.
+// Returns nil if the frame is too large to enumerate.
+func (wf *WeightFunction) Mass() (mf *MassFunction) {
+	weights, frame, bound, err := wf.dense()
+	if err != nil {
+		return nil
+	}
+	mf = &MassFunction{}
+	mf.bind(frame, bound)
+	var masses []float64
+	if wf.disjunctive {
+		masses = massesFromDisjunctiveWeights(weights)
+	} else {
+		masses = massesFromConjunctiveWeights(weights)
+	}
+	mf.setDenseUnsafe(masses, true)
+	mf.openWorld = mf.value(bitKey{}) != 0.0
+	return mf
+}
+
+// Weights returns the conjunctive weights of the canonical decomposition of
+// the MassFunction with this commonality function. Returns an error if the
+// commonality of the whole frame is zero, i.e. the MassFunction is dogmatic, if
+// any other commonality isn't positive, or if the frame is too large to
+// enumerate.
+func (cf *CommonalityFunction) Weights() (*WeightFunction, error) {
+	v, frame, bound, err := cf.dense()
+	if err != nil {
+		return nil, err
+	}
+	if v[len(v)-1] <= 0.0 {
+		return nil, errDogmatic
+	}
+	if !positive(v) {
+		return nil, errNotPositive
+	}
+	return newWeightFunction(frame, bound, commonalityWeights(v), false), nil
+}
+
+// Weights returns the disjunctive weights of the canonical decomposition of
+// the MassFunction with this belief function. Returns an error if the belief in
+// the empty set is zero, i.e. the MassFunction is normal, if any other belief
+// isn't positive, or if the frame is too large to enumerate.
+func (bf *BeliefFunction) Weights() (*WeightFunction, error) {
+	v, frame, bound, err := bf.dense()
+	if err != nil {
+		return nil, err
+	}
+	if v[0] <= 0.0 {
+		return nil, errNormal
+	}
+	if !positive(v) {
+		return nil, errNotPositive
+	}
+	return newWeightFunction(frame, bound, implicabilityWeights(v), true), nil
+}
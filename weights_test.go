@@ -0,0 +1,258 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConjunctiveWeights(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// A simple support function has a single weight below 1.0
+	mf := &MassFunction{}
+	mf.Set(K("a", "b"), 0.6)
+	mf.Set(K("a", "b", "c"), 0.4)
+	wf, err := mf.ConjunctiveWeights()
+	assert.Nil(err)
+	assert.False(wf.Disjunctive())
+	assert.InDelta(0.4, wf.Get(K("a", "b")), tolerance)
+	assert.InDelta(1.0, wf.Get(K("a")), tolerance)
+	assert.InDelta(1.0, wf.Get(K()), tolerance)
+	assert.True(wf.Valid())
+	assert.True(wf.Separable())
+
+	// {a,b}^0.3 combined with {b,c}^0.5
+	mf = &MassFunction{}
+	mf.Set(K("b"), 0.35)
+	mf.Set(K("a", "b"), 0.35)
+	mf.Set(K("b", "c"), 0.15)
+	mf.Set(K("a", "b", "c"), 0.15)
+	wf, err = mf.ConjunctiveWeights()
+	assert.Nil(err)
+	assert.InDelta(0.3, wf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.5, wf.Get(K("b", "c")), tolerance)
+	assert.InDelta(1.0, wf.Get(K("b")), tolerance)
+	assert.InDelta(1.0, wf.Get(K("a", "c")), tolerance)
+	assert.True(wf.Separable())
+
+	// Mass functions that aren't separable have weights above 1.0
+	mf = &MassFunction{}
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("b"), 0.3)
+	mf.Set(K("a", "b"), 0.2)
+	wf, err = mf.ConjunctiveWeights()
+	assert.Nil(err)
+	assert.True(wf.Valid())
+	assert.False(wf.Separable())
+	assert.InDelta(0.7*0.5/0.2, wf.Get(K()), tolerance)
+
+	// Dogmatic mass functions can't be decomposed
+	mf = &MassFunction{}
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("b"), 0.5)
+	_, err = mf.ConjunctiveWeights()
+	assert.NotNil(err)
+}
+
+func TestDisjunctiveWeights(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	// {a}_0.4 combined disjunctively with {b}_0.5
+	mf := &MassFunction{}
+	mf.SetOpenWorld(true)
+	mf.Set(K(), 0.2)
+	mf.Set(K("a"), 0.3)
+	mf.Set(K("b"), 0.2)
+	mf.Set(K("a", "b"), 0.3)
+	wf, err := mf.DisjunctiveWeights()
+	assert.Nil(err)
+	assert.True(wf.Disjunctive())
+	assert.InDelta(0.4, wf.Get(K("a")), tolerance)
+	assert.InDelta(0.5, wf.Get(K("b")), tolerance)
+	assert.InDelta(1.0, wf.Get(K("a", "b")), tolerance)
+	assert.True(wf.Valid())
+
+	// Normal mass functions can't be decomposed
+	mf = &MassFunction{}
+	mf.Set(K("a"), 0.5)
+	mf.Set(K("a", "b"), 0.5)
+	_, err = mf.DisjunctiveWeights()
+	assert.NotNil(err)
+}
+
+func TestWeightsRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	mf := trafficLight()
+	frame := mf.Frame()
+	v, err := mf.denseOver(frame)
+	assert.Nil(err)
+	weights, err := conjunctiveWeights(v)
+	assert.Nil(err)
+	assert.InDeltaSlice(v, massesFromConjunctiveWeights(weights), tolerance)
+
+	v[0] = 0.1
+	v[7] = 0.0
+	weights, err = disjunctiveWeights(v)
+	assert.Nil(err)
+	assert.InDeltaSlice(v, massesFromDisjunctiveWeights(weights), tolerance)
+}
+
+func TestWeightFunctionMass(t *testing.T) {
+	const tolerance = 0.00001
+
+	tcs := []struct {
+		name    string
+		weights func(*MassFunction) (*WeightFunction, error)
+	}{
+		{
+			name:    "conjunctive",
+			weights: (*MassFunction).ConjunctiveWeights,
+		},
+		{
+			name: "commonality",
+			weights: func(mf *MassFunction) (*WeightFunction, error) {
+				return mf.Commonality().Weights()
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mf := trafficLight()
+			wf, err := tc.weights(mf)
+			assert.Nil(err)
+			rmf := wf.Mass()
+			assert.True(rmf.Frame() == mf.Frame())
+			for _, p := range mf.Powerset() {
+				assert.InDelta(mf.Get(p), rmf.Get(p), 0.0001, p.String())
+			}
+			assert.False(rmf.OpenWorld())
+			assert.True(rmf.Valid())
+		})
+	}
+
+	t.Run("disjunctive", func(t *testing.T) {
+		assert := assert.New(t)
+		mf := &MassFunction{}
+		mf.SetOpenWorld(true)
+		mf.Set(K(), 0.2)
+		mf.Set(K("a"), 0.3)
+		mf.Set(K("b"), 0.2)
+		mf.Set(K("a", "b"), 0.3)
+		wf, err := mf.Belief().Weights()
+		assert.Nil(err)
+		assert.True(wf.Disjunctive())
+		assert.InDelta(0.4, wf.Get(K("a")), tolerance)
+		assert.InDelta(0.5, wf.Get(K("b")), tolerance)
+		rmf := wf.Mass()
+		assert.True(rmf.OpenWorld())
+		for _, p := range mf.Powerset() {
+			assert.InDelta(mf.Get(p), rmf.Get(p), tolerance, p.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert := assert.New(t)
+		mf := &MassFunction{}
+		mf.Set(K("a"), 0.5)
+		mf.Set(K("b"), 0.5)
+		_, err := mf.Commonality().Weights()
+		assert.NotNil(err)
+		_, err = mf.Belief().Weights()
+		assert.NotNil(err)
+
+		// Functions with unset values would have infinite weights
+		fr, _ := NewFrame("a", "b")
+		cf := NewCommonalityFunction(fr)
+		cf.Set(K(), 1.0)
+		cf.Set(K("a"), 0.7)
+		cf.Set(K("a", "b"), 0.4)
+		_, err = cf.Weights()
+		assert.Equal(errNotPositive, err)
+		bf := NewBeliefFunction(fr)
+		bf.Set(K(), 0.2)
+		bf.Set(K("a"), 0.5)
+		bf.Set(K("a", "b"), 1.0)
+		_, err = bf.Weights()
+		assert.Equal(errNotPositive, err)
+	})
+}
+
+func TestNewWeightFunction(t *testing.T) {
+	assert := assert.New(t)
+	const tolerance = 0.00001
+
+	fr, _ := NewFrame("a", "b", "c")
+	wf := NewWeightFunction(fr, false)
+	assert.True(wf.Bound())
+	assert.False(wf.Disjunctive())
+	assert.Nil(wf.Set(K("a", "b"), 0.3))
+	assert.Nil(wf.Set(K("b", "c"), 0.5))
+	assert.NotNil(wf.Set(K("a"), 0.0))
+	assert.NotNil(wf.Set(K("a"), -0.5))
+	assert.NotNil(wf.Set(K("d"), 0.5))
+	assert.Equal("{a,b}\t0.300000\n{b,c}\t0.500000\n", wf.String())
+
+	// {a,b}^0.3 combined with {b,c}^0.5
+	mf := wf.Mass()
+	assert.True(mf.Bound())
+	assert.InDelta(0.35, mf.Get(K("b")), tolerance)
+	assert.InDelta(0.35, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.15, mf.Get(K("b", "c")), tolerance)
+	assert.InDelta(0.15, mf.Get(K("a", "b", "c")), tolerance)
+	assert.True(mf.Valid())
+
+	// Weights above 1.0 retract support, allowing mass functions that aren't
+	// separable to be rebuilt
+	fr, _ = NewFrame("a", "b")
+	wf = NewWeightFunction(fr, false)
+	assert.Nil(wf.Set(K("a"), 0.2/0.7))
+	assert.Nil(wf.Set(K("b"), 0.4))
+	assert.Nil(wf.Set(K(), 1.75))
+	assert.False(wf.Separable())
+	mf = wf.Mass()
+	assert.InDelta(0.5, mf.Get(K("a")), tolerance)
+	assert.InDelta(0.3, mf.Get(K("b")), tolerance)
+	assert.InDelta(0.2, mf.Get(K("a", "b")), tolerance)
+	assert.InDelta(0.0, mf.Get(K()), tolerance)
+	assert.False(mf.OpenWorld())
+	assert.True(mf.Valid())
+
+	// Unbound weight functions grow their frame
+	wf = &WeightFunction{}
+	assert.Nil(wf.Set(K("x", "y"), 2.0))
+	assert.Equal(2, wf.Frame().Len())
+	assert.InDelta(2.0, wf.Get(K("x", "y")), tolerance)
+}
+
+func TestWeightsLargeFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	frame, hypotheses := largeFrame()
+	mf := NewMassFunction(frame)
+	mf.Set(K("h0"), 0.5)
+	mf.Set(K(hypotheses...), 0.5)
+	wf := NewWeightFunction(frame, false)
+	wf.Set(K("h0"), 0.5)
+	bf := NewBeliefFunction(frame)
+	bf.Set(K("h0"), 0.5)
+	cf := NewCommonalityFunction(frame)
+	cf.Set(K("h0"), 0.5)
+
+	_, err := mf.ConjunctiveWeights()
+	assert.Equal(errFrameTooLarge, err)
+	_, err = mf.DisjunctiveWeights()
+	assert.Equal(errFrameTooLarge, err)
+	_, err = bf.Weights()
+	assert.Equal(errFrameTooLarge, err)
+	_, err = cf.Weights()
+	assert.Equal(errFrameTooLarge, err)
+	assert.Nil(wf.Mass())
+	assert.InDelta(0.5, wf.Get(K("h0")), 0.00001)
+}